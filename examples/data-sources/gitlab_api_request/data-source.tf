@@ -0,0 +1,19 @@
+data "gitlab_api_request" "license" {
+  path = "license"
+}
+
+output "licensee" {
+  value = jsondecode(data.gitlab_api_request.license.result).licensee
+}
+
+# all online runners of the instance
+data "gitlab_api_request" "runners" {
+  path = "runners/all"
+  query = {
+    status = "online"
+  }
+}
+
+output "runner_descriptions" {
+  value = [for runner in jsondecode(data.gitlab_api_request.runners.result) : runner.description]
+}
@@ -0,0 +1,22 @@
+data "gitlab_graphql_query" "compliance_frameworks" {
+  query = <<-EOT
+    query($fullPath: ID!) {
+      namespace(fullPath: $fullPath) {
+        complianceFrameworks {
+          nodes {
+            id
+            name
+          }
+        }
+      }
+    }
+  EOT
+
+  variables = jsonencode({
+    fullPath = "example"
+  })
+}
+
+output "compliance_framework_names" {
+  value = [for framework in jsondecode(data.gitlab_graphql_query.compliance_frameworks.result).namespace.complianceFrameworks.nodes : framework.name]
+}
@@ -0,0 +1,12 @@
+resource "gitlab_api_resource" "hook" {
+  create_path = "projects/example%2Fhooked/hooks"
+  read_path   = "projects/example%2Fhooked/hooks/{id}"
+
+  body = jsonencode({
+    url                   = "https://example.com/hook"
+    push_events           = true
+    merge_requests_events = true
+  })
+
+  tracked_attributes = ["url", "push_events", "merge_requests_events"]
+}
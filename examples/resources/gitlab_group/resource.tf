@@ -10,3 +10,10 @@ resource "gitlab_project" "example" {
   description  = "An example project"
   namespace_id = gitlab_group.example.id
 }
+
+# Group with an avatar
+resource "gitlab_group" "example-avatar" {
+  name   = "example-avatar"
+  path   = "example-avatar"
+  avatar = "${path.module}/avatar.png"
+}
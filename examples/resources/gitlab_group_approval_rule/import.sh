@@ -0,0 +1,2 @@
+# GitLab group approval rules can be imported using a key composed of `<group-id>:<rule-id>`, e.g.
+terraform import gitlab_group_approval_rule.example "12345:6"
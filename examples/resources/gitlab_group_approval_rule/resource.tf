@@ -0,0 +1,15 @@
+resource "gitlab_group_approval_rule" "example-one" {
+  group              = 5
+  name               = "Security team must approve"
+  approvals_required = 1
+  group_ids          = [51]
+}
+
+# With a report type
+resource "gitlab_group_approval_rule" "example-two" {
+  group              = 5
+  name               = "Coverage-Check"
+  approvals_required = 1
+  user_ids           = [50, 500]
+  report_type        = "code_coverage"
+}
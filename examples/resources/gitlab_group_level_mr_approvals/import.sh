@@ -0,0 +1,2 @@
+# GitLab group MR approval settings can be imported using the id or full path of the group, e.g.
+terraform import gitlab_group_level_mr_approvals.foo "53"
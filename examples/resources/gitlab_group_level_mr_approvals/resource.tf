@@ -0,0 +1,13 @@
+resource "gitlab_group" "foo" {
+  name = "Example"
+  path = "example"
+}
+
+resource "gitlab_group_level_mr_approvals" "foo" {
+  group                                          = gitlab_group.foo.id
+  reset_approvals_on_push                        = true
+  disable_overriding_approvers_per_merge_request = true
+  merge_requests_author_approval                 = false
+  merge_requests_disable_committers_approval     = true
+  require_password_to_approve                    = true
+}
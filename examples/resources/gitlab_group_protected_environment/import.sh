@@ -0,0 +1,2 @@
+# GitLab group protected environments can be imported using a key composed of `<group-id>:<deployment-tier>`, e.g.
+terraform import gitlab_group_protected_environment.production "123:production"
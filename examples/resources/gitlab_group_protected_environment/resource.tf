@@ -0,0 +1,12 @@
+resource "gitlab_group_protected_environment" "production" {
+  group       = 123
+  environment = "production"
+
+  deploy_access_levels {
+    access_level = "maintainer"
+  }
+
+  deploy_access_levels {
+    user_id = 456
+  }
+}
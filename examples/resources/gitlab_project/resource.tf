@@ -16,3 +16,9 @@ resource "gitlab_project" "example-two" {
     prevent_secrets        = true
   }
 }
+
+# Fork of another project
+resource "gitlab_project" "example-fork" {
+  name                   = "example-fork"
+  forked_from_project_id = gitlab_project.example.id
+}
@@ -44,3 +44,15 @@ resource "gitlab_branch_protection" "any-approver" {
   rule_type          = "any_approver"
   approvals_required = 1
 }
+
+# Example requiring an approval for new critical vulnerabilities
+resource "gitlab_project_approval_rule" "vulnerability-check" {
+  project              = 5
+  name                 = "Vulnerability-Check"
+  approvals_required   = 1
+  group_ids            = [51]
+  report_type          = "vulnerability"
+  scanners             = ["sast", "dependency_scanning"]
+  severity_levels      = ["high", "critical"]
+  vulnerability_states = ["newly_detected"]
+}
@@ -0,0 +1,2 @@
+# GitLab project approval rules can be imported using the id or full path of the project, e.g.
+terraform import gitlab_project_approval_rules.example "12345"
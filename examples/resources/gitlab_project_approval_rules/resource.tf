@@ -0,0 +1,16 @@
+resource "gitlab_project_approval_rules" "example" {
+  project = 5
+
+  rule {
+    name               = "Security team must approve"
+    approvals_required = 1
+    group_ids          = [51]
+  }
+
+  rule {
+    name               = "Coverage-Check"
+    approvals_required = 1
+    user_ids           = [50, 500]
+    report_type        = "code_coverage"
+  }
+}
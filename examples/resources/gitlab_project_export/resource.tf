@@ -0,0 +1,10 @@
+resource "gitlab_project_export" "example" {
+  project = "my-group/my-project"
+  path    = "${path.module}/my-project.tar.gz"
+}
+
+# Import the export as a new project, e.g. with a provider configured for another GitLab instance
+resource "gitlab_project" "imported" {
+  name        = "my-project"
+  import_file = gitlab_project_export.example.path
+}
@@ -0,0 +1,2 @@
+# GitLab protected environments can be imported using a key composed of `<project-id>:<environment-name>`, e.g.
+terraform import gitlab_project_protected_environment.production "123:production"
@@ -0,0 +1,13 @@
+resource "gitlab_project_protected_environment" "production" {
+  project                 = 123
+  environment             = "production"
+  required_approval_count = 1
+
+  deploy_access_levels {
+    access_level = "maintainer"
+  }
+
+  deploy_access_levels {
+    group_id = 456
+  }
+}
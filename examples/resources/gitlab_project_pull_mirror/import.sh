@@ -0,0 +1,2 @@
+# GitLab project pull mirrors can be imported using the id or full path of the project, e.g.
+terraform import gitlab_project_pull_mirror.example "12345"
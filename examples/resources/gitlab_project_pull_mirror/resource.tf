@@ -0,0 +1,18 @@
+resource "gitlab_project" "example" {
+  name = "example"
+
+  # The pull mirror is managed by the gitlab_project_pull_mirror resource.
+  lifecycle {
+    ignore_changes = [mirror, mirror_trigger_builds, mirror_overwrites_diverged_branches, only_mirror_protected_branches]
+  }
+}
+
+resource "gitlab_project_pull_mirror" "example" {
+  project  = gitlab_project.example.id
+  url      = "https://github.com/org/repository.git"
+  username = "mirror-bot"
+  password = var.mirror_token
+
+  trigger_builds               = true
+  overwrites_diverged_branches = true
+}
@@ -55,6 +55,11 @@ var validProtectedBranchUnprotectAccessLevelNames = []string{
 	"developer", "maintainer",
 }
 
+// The access levels allowed to deploy to a protected environment
+var validProtectedEnvironmentDeploymentLevelNames = []string{
+	"developer", "maintainer",
+}
+
 var accessLevelNameToValue = map[string]gitlab.AccessLevelValue{
 	"no one":     gitlab.NoPermissions,
 	"minimal":    gitlab.MinimalAccessPermissions,
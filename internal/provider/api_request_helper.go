@@ -0,0 +1,82 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/url"
+	"strconv"
+	"strings"
+
+	retryablehttp "github.com/hashicorp/go-retryablehttp"
+	gitlab "github.com/xanzy/go-gitlab"
+)
+
+// gitlabAPIRequest sends a request to an arbitrary path of the GitLab REST API, relative to the `/api/v4/` base URL,
+// using the authenticated client of the provider. The body (if any) is sent as JSON.
+// It returns the raw JSON response body, which is nil for empty responses.
+func gitlabAPIRequest(ctx context.Context, client *gitlab.Client, method string, path string, query url.Values, body interface{}) (json.RawMessage, *gitlab.Response, error) {
+	withQuery := func(req *retryablehttp.Request) error {
+		req.URL.RawQuery = query.Encode()
+		return nil
+	}
+
+	req, err := client.NewRequest(method, strings.TrimPrefix(path, "/"), body, []gitlab.RequestOptionFunc{gitlab.WithContext(ctx), withQuery})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var buf bytes.Buffer
+	resp, err := client.Do(req, &buf)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	if len(bytes.TrimSpace(buf.Bytes())) == 0 {
+		return nil, resp, nil
+	}
+	return buf.Bytes(), resp, nil
+}
+
+// gitlabAPIGetAllPages sends a GET request to an arbitrary path of the GitLab REST API.
+// If the response is a list, the following pages (as given by the `X-Next-Page` header) are requested as well,
+// up to maxPages pages in total (0 means all), and the items of all pages are combined into a single JSON array.
+func gitlabAPIGetAllPages(ctx context.Context, client *gitlab.Client, path string, query url.Values, maxPages int) (json.RawMessage, error) {
+	pageQuery := url.Values{}
+	for k, v := range query {
+		pageQuery[k] = v
+	}
+
+	var items []json.RawMessage
+	page := 1
+	for fetched := 1; ; fetched++ {
+		if page > 1 {
+			pageQuery.Set("page", strconv.Itoa(page))
+		}
+
+		result, resp, err := gitlabAPIRequest(ctx, client, "GET", path, pageQuery, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		var pageItems []json.RawMessage
+		if err := json.Unmarshal(result, &pageItems); err != nil {
+			// Not a list, thus there is nothing to paginate.
+			if page == 1 {
+				return result, nil
+			}
+			return nil, err
+		}
+		items = append(items, pageItems...)
+
+		if resp.NextPage == 0 || (maxPages > 0 && fetched >= maxPages) {
+			break
+		}
+		page = resp.NextPage
+	}
+
+	if items == nil {
+		items = []json.RawMessage{}
+	}
+	return json.Marshal(items)
+}
@@ -0,0 +1,66 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"testing"
+
+	gitlab "github.com/xanzy/go-gitlab"
+)
+
+func TestGitlabAPIGetAllPages(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v4/runners/all":
+			if r.URL.Query().Get("status") != "online" {
+				t.Errorf("expected query parameter to be sent, got: %q", r.URL.RawQuery)
+			}
+			page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+			if page == 0 {
+				page = 1
+			}
+			if page < 3 {
+				w.Header().Set("X-Next-Page", strconv.Itoa(page+1))
+			}
+			fmt.Fprintf(w, `[{"id": %d}]`, page)
+		case "/api/v4/license":
+			fmt.Fprint(w, `{"plan": "ultimate"}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := gitlab.NewClient("token", gitlab.WithBaseURL(server.URL))
+	if err != nil {
+		t.Fatalf("could not create client: %v", err)
+	}
+
+	cases := []struct {
+		Path     string
+		MaxPages int
+		Expected string
+	}{
+		{Path: "runners/all", MaxPages: 0, Expected: `[{"id":1},{"id":2},{"id":3}]`},
+		{Path: "/runners/all", MaxPages: 2, Expected: `[{"id":1},{"id":2}]`},
+		{Path: "license", MaxPages: 0, Expected: `{"plan": "ultimate"}`},
+	}
+
+	for _, tc := range cases {
+		result, err := gitlabAPIGetAllPages(context.Background(), client, tc.Path, url.Values{"status": {"online"}}, tc.MaxPages)
+		if err != nil {
+			t.Fatalf("%s: request failed: %v", tc.Path, err)
+		}
+		if string(result) != tc.Expected {
+			t.Fatalf("%s: got %s expected %s", tc.Path, result, tc.Expected)
+		}
+	}
+
+	if _, err := gitlabAPIGetAllPages(context.Background(), client, "unknown", nil, 0); !is404(err) {
+		t.Fatalf("expected 404 error, got: %v", err)
+	}
+}
@@ -0,0 +1,83 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	gitlab "github.com/xanzy/go-gitlab"
+)
+
+// avatarSchema returns the schema of the avatar attributes shared by the project and group resources.
+// The given resourceType is used in the descriptions, e.g. "project".
+func avatarSchema(resourceType string) map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"avatar": {
+			Description: fmt.Sprintf("A local path to the avatar image to upload for the %s. Removing it removes the avatar of the %s. **Note**: not available for imported resources.", resourceType, resourceType),
+			Type:        schema.TypeString,
+			Optional:    true,
+		},
+		"avatar_hash": {
+			Description: "The SHA256 hash of the avatar image, which makes changes to the image file show up in the plan.",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"avatar_url": {
+			Description: fmt.Sprintf("The URL of the avatar image of the %s.", resourceType),
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+	}
+}
+
+// customizeDiffAvatarHash plans `avatar_hash` as the hash of the local avatar file,
+// so that changes to the content of the file trigger an update of the avatar.
+func customizeDiffAvatarHash(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("avatar") {
+		return d.SetNewComputed("avatar_hash")
+	}
+
+	hash := ""
+	if avatar := d.Get("avatar").(string); avatar != "" {
+		var err error
+		if hash, err = fileSHA256(avatar); err != nil {
+			return fmt.Errorf("failed to read avatar: %w", err)
+		}
+	}
+
+	if d.Get("avatar_hash").(string) == hash {
+		return nil
+	}
+	return d.SetNew("avatar_hash", hash)
+}
+
+// updateAvatar uploads the avatar file at the given path to the object at the given API path, e.g. `groups/42`.
+// An empty avatar path removes the avatar of the object.
+func updateAvatar(ctx context.Context, client *gitlab.Client, apiPath string, avatar string) error {
+	if avatar == "" {
+		log.Printf("[DEBUG] remove avatar of %q", apiPath)
+
+		_, _, err := gitlabAPIRequest(ctx, client, http.MethodPut, apiPath, nil, map[string]string{"avatar": ""})
+		return err
+	}
+
+	log.Printf("[DEBUG] upload avatar %q to %q", avatar, apiPath)
+
+	f, err := os.Open(avatar)
+	if err != nil {
+		return fmt.Errorf("failed to read avatar: %w", err)
+	}
+	defer f.Close()
+
+	req, err := client.UploadRequest(http.MethodPut, apiPath, f, filepath.Base(avatar), gitlab.UploadAvatar, nil, []gitlab.RequestOptionFunc{gitlab.WithContext(ctx)})
+	if err != nil {
+		return err
+	}
+
+	_, err = client.Do(req, nil)
+	return err
+}
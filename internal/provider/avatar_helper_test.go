@@ -0,0 +1,105 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	gitlab "github.com/xanzy/go-gitlab"
+)
+
+func TestUpdateAvatar(t *testing.T) {
+	var uploaded, removed bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut || r.URL.Path != "/api/v4/groups/42" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		if file, header, err := r.FormFile("avatar"); err == nil {
+			content, _ := io.ReadAll(file)
+			if header.Filename != "avatar.png" || string(content) != "avatar" {
+				t.Errorf("unexpected avatar upload %q: %q", header.Filename, content)
+			}
+			uploaded = true
+		} else {
+			var body map[string]string
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				t.Errorf("could not decode body: %v", err)
+			}
+			if v, ok := body["avatar"]; !ok || v != "" {
+				t.Errorf("expected avatar to be removed, got body: %v", body)
+			}
+			removed = true
+		}
+		w.Write([]byte(`{"id": 42}`))
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := gitlab.NewClient("token", gitlab.WithBaseURL(server.URL))
+	if err != nil {
+		t.Fatalf("could not create client: %v", err)
+	}
+
+	avatar := filepath.Join(t.TempDir(), "avatar.png")
+	if err := os.WriteFile(avatar, []byte("avatar"), 0600); err != nil {
+		t.Fatalf("could not write avatar: %v", err)
+	}
+
+	if err := updateAvatar(context.Background(), client, "groups/42", avatar); err != nil || !uploaded {
+		t.Fatalf("expected avatar to be uploaded, got: %v", err)
+	}
+	if err := updateAvatar(context.Background(), client, "groups/42", ""); err != nil || !removed {
+		t.Fatalf("expected avatar to be removed, got: %v", err)
+	}
+}
+
+// testAccWriteAvatar writes a small PNG image with the given color to the given path.
+func testAccWriteAvatar(t *testing.T, path string, c color.Color) {
+	img := image.NewRGBA(image.Rect(0, 0, 32, 32))
+	for x := 0; x < 32; x++ {
+		for y := 0; y < 32; y++ {
+			img.Set(x, y, c)
+		}
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatalf("could not create avatar: %v", err)
+	}
+	defer f.Close()
+
+	if err := png.Encode(f, img); err != nil {
+		t.Fatalf("could not write avatar: %v", err)
+	}
+}
+
+// testAccCheckGitlabAvatarHash checks that the `avatar_hash` of the given resource is the hash of the avatar file at the given path.
+func testAccCheckGitlabAvatarHash(name string, path string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("not found: %s", name)
+		}
+
+		hash, err := fileSHA256(path)
+		if err != nil {
+			return err
+		}
+		if got := rs.Primary.Attributes["avatar_hash"]; got != hash {
+			return fmt.Errorf("got avatar_hash %q, expected %q", got, hash)
+		}
+		return nil
+	}
+}
@@ -1,24 +1,80 @@
 package provider
 
 import (
+	"context"
 	"crypto/tls"
 	"crypto/x509"
+	"fmt"
 	"io/ioutil"
+	"log"
+	"math"
 	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
 
+	"github.com/hashicorp/go-retryablehttp"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/logging"
 	"github.com/xanzy/go-gitlab"
+	"golang.org/x/net/http/httpproxy"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/clientcredentials"
+	"golang.org/x/time/rate"
 )
 
+// Authentication methods supported by the provider.
+const (
+	// authMethodToken uses an OAuth2, project, group or personal access token as Bearer token.
+	authMethodToken = "token"
+	// authMethodJobToken uses a CI job token in the `JOB-TOKEN` header.
+	authMethodJobToken = "job_token"
+	// authMethodPassword uses the OAuth2 password grant to request (and refresh) a token with a username and password.
+	authMethodPassword = "password"
+	// authMethodClientCredentials uses the OAuth2 client credentials grant to request (and refresh) a token for an application.
+	authMethodClientCredentials = "client_credentials"
+)
+
+var validAuthMethods = []string{authMethodToken, authMethodJobToken, authMethodPassword, authMethodClientCredentials}
+
 // Config is per-provider, specifies where to connect to gitlab
 type Config struct {
+	// AuthMethod is one of validAuthMethods, it defaults to authMethodToken.
+	AuthMethod    string
 	Token         string
+	Username      string
+	Password      string
+	ClientID      string
+	ClientSecret  string
 	BaseURL       string
 	Insecure      bool
 	CACertFile    string
 	ClientCert    string
 	ClientKey     string
 	EarlyAuthFail bool
+
+	// ProxyURL is the URL of the proxy used for all requests. If empty, the proxy is taken from the
+	// HTTP_PROXY, HTTPS_PROXY and NO_PROXY environment variables.
+	ProxyURL string
+	// NoProxy is a comma-separated list of hosts which are requested without the proxy.
+	// It overrides the NO_PROXY environment variable.
+	NoProxy string
+	// Headers are added to every request, e.g. to authenticate against a proxy in front of GitLab.
+	Headers map[string]string
+	// ReadOnly rejects all API requests which may change data in GitLab.
+	ReadOnly bool
+
+	// MaxRetries is the number of times a request is retried after a rate limit (429) or server (5xx) error.
+	MaxRetries int
+	// RetryWaitMin and RetryWaitMax bound the exponential backoff between retries,
+	// unless the response tells us how long to wait.
+	RetryWaitMin time.Duration
+	RetryWaitMax time.Duration
+	// RequestsPerSecond limits the client side request rate. Zero uses the rate limit advertised by GitLab.
+	RequestsPerSecond float64
+
+	// authenticatedUser is the user fetched by the early auth check, if it ran.
+	authenticatedUser *gitlab.User
 }
 
 // Client returns a *gitlab.Client to interact with the configured gitlab instance
@@ -56,30 +112,278 @@ func (c *Config) Client() (*gitlab.Client, error) {
 	t.TLSClientConfig = tlsConfig
 	t.MaxIdleConnsPerHost = 100
 
+	if c.ProxyURL != "" || c.NoProxy != "" {
+		proxyConfig := httpproxy.FromEnvironment()
+		if c.ProxyURL != "" {
+			proxyConfig.HTTPProxy = c.ProxyURL
+			proxyConfig.HTTPSProxy = c.ProxyURL
+		}
+		if c.NoProxy != "" {
+			proxyConfig.NoProxy = c.NoProxy
+		}
+		proxyFunc := proxyConfig.ProxyFunc()
+		t.Proxy = func(req *http.Request) (*url.URL, error) {
+			return proxyFunc(req.URL)
+		}
+	}
+
+	// The custom headers are added below the logging transport, to not leak them into the logs.
+	var base http.RoundTripper = t
+	if len(c.Headers) > 0 {
+		base = &headerTransport{headers: c.Headers, base: t}
+	}
+
+	// Retries are handled by our own transport, so that they are configurable by the user.
+	// This is why the go-gitlab internal retry logic is disabled below.
+	retryClient := &retryablehttp.Client{
+		HTTPClient: &http.Client{
+			Transport: logging.NewTransport("GitLab", base),
+		},
+		RetryMax:       c.MaxRetries,
+		RetryWaitMin:   c.RetryWaitMin,
+		RetryWaitMax:   c.RetryWaitMax,
+		CheckRetry:     retryPolicy,
+		Backoff:        retryBackoff,
+		ErrorHandler:   retryablehttp.PassthroughErrorHandler,
+		RequestLogHook: logRetry,
+	}
+
+	// The read-only mode is enforced above the retries, because blocked requests must not be retried.
+	var apiTransport http.RoundTripper = &retryMethodTransport{base: &retryablehttp.RoundTripper{Client: retryClient}}
+	if c.ReadOnly {
+		apiTransport = &readOnlyTransport{base: apiTransport}
+	}
+
 	opts := []gitlab.ClientOptionFunc{
 		gitlab.WithHTTPClient(
 			&http.Client{
-				Transport: logging.NewTransport("GitLab", t),
+				Transport: apiTransport,
 			},
 		),
+		gitlab.WithoutRetries(),
+	}
+
+	if c.RequestsPerSecond > 0 {
+		// Allow a burst of (at least) one second worth of requests.
+		burst := int(math.Max(1, c.RequestsPerSecond))
+		opts = append(opts, gitlab.WithCustomLimiter(rate.NewLimiter(rate.Limit(c.RequestsPerSecond), burst)))
 	}
 
 	if c.BaseURL != "" {
 		opts = append(opts, gitlab.WithBaseURL(c.BaseURL))
 	}
 
-	// The OAuth method is also compatible with project/group/personal access and job tokens because they are all usable as Bearer tokens.
-	// Although the job token API access is very limited.
-	// see https://docs.gitlab.com/ee/api#authentication
-	client, err := gitlab.NewOAuthClient(c.Token, opts...)
+	var client *gitlab.Client
+	var err error
+	switch c.AuthMethod {
+	case "", authMethodToken:
+		// The OAuth method is also compatible with project/group/personal access and job tokens because they are all usable as Bearer tokens.
+		// Although the job token API access is very limited.
+		// see https://docs.gitlab.com/ee/api#authentication
+		client, err = gitlab.NewOAuthClient(c.Token, opts...)
+	case authMethodJobToken:
+		client, err = gitlab.NewJobClient(c.Token, opts...)
+	case authMethodPassword:
+		client, err = c.passwordClient(base, apiTransport, opts)
+	case authMethodClientCredentials:
+		client, err = c.clientCredentialsClient(base, apiTransport, opts)
+	default:
+		return nil, fmt.Errorf("unsupported authentication method %q, must be one of %s", c.AuthMethod, strings.Join(validAuthMethods, ", "))
+	}
 	if err != nil {
 		return nil, err
 	}
 
 	// Test the credentials by checking we can get information about the authenticated user.
-	if c.EarlyAuthFail {
-		_, _, err = client.Users.CurrentUser()
+	// Job tokens are not allowed to access the user API, thus the check is skipped for them.
+	if c.EarlyAuthFail && c.AuthMethod != authMethodJobToken {
+		c.authenticatedUser, _, err = client.Users.CurrentUser()
 	}
 
 	return client, err
 }
+
+// clientCredentialsClient returns a client which authenticates with a token requested using the OAuth2
+// client credentials grant. The token is refreshed automatically once it expires.
+func (c *Config) clientCredentialsClient(t http.RoundTripper, apiTransport http.RoundTripper, opts []gitlab.ClientOptionFunc) (*gitlab.Client, error) {
+	tokenURL, err := c.oauthTokenURL()
+	if err != nil {
+		return nil, err
+	}
+
+	config := &clientcredentials.Config{
+		ClientID:     c.ClientID,
+		ClientSecret: c.ClientSecret,
+		TokenURL:     tokenURL,
+	}
+
+	return oauthTokenSourceClient(config.TokenSource(oauthTokenContext(t)), apiTransport, opts)
+}
+
+// passwordClient returns a client which authenticates with a token requested using the OAuth2
+// password grant. A new token is requested once it expires.
+// The token isn't requested by go-gitlab, because it would use the logging transport and leak the password into the logs.
+func (c *Config) passwordClient(t http.RoundTripper, apiTransport http.RoundTripper, opts []gitlab.ClientOptionFunc) (*gitlab.Client, error) {
+	tokenURL, err := c.oauthTokenURL()
+	if err != nil {
+		return nil, err
+	}
+
+	source := &passwordTokenSource{
+		ctx: oauthTokenContext(t),
+		config: &oauth2.Config{
+			Endpoint: oauth2.Endpoint{TokenURL: tokenURL},
+		},
+		username: c.Username,
+		password: c.Password,
+	}
+
+	return oauthTokenSourceClient(oauth2.ReuseTokenSource(nil, source), apiTransport, opts)
+}
+
+// oauthTokenURL returns the URL of the OAuth2 token endpoint of the GitLab instance.
+func (c *Config) oauthTokenURL() (string, error) {
+	baseURL := c.BaseURL
+	if baseURL == "" {
+		baseURL = "https://gitlab.com/"
+	}
+	u, err := url.Parse(baseURL)
+	if err != nil {
+		return "", err
+	}
+	u.Path = strings.TrimSuffix(strings.TrimSuffix(u.Path, "/"), "/api/v4") + "/oauth/token"
+	return u.String(), nil
+}
+
+// oauthTokenContext returns the context to request OAuth2 tokens with. The token endpoint is called with
+// the same TLS, proxy and header settings as the API, but without logging to not leak credentials into the logs.
+func oauthTokenContext(t http.RoundTripper) context.Context {
+	return context.WithValue(context.Background(), oauth2.HTTPClient, &http.Client{Transport: t})
+}
+
+// oauthTokenSourceClient returns a client which authenticates with the tokens of the given source.
+func oauthTokenSourceClient(source oauth2.TokenSource, apiTransport http.RoundTripper, opts []gitlab.ClientOptionFunc) (*gitlab.Client, error) {
+	// The oauth2 transport sets the Authorization header, overriding the one set by go-gitlab.
+	opts = append(opts, gitlab.WithHTTPClient(
+		&http.Client{
+			Transport: &oauth2.Transport{
+				Source: source,
+				Base:   apiTransport,
+			},
+		},
+	))
+
+	return gitlab.NewOAuthClient("", opts...)
+}
+
+// passwordTokenSource is an oauth2.TokenSource which requests tokens using the OAuth2 password grant.
+type passwordTokenSource struct {
+	ctx      context.Context
+	config   *oauth2.Config
+	username string
+	password string
+}
+
+func (s *passwordTokenSource) Token() (*oauth2.Token, error) {
+	return s.config.PasswordCredentialsToken(s.ctx, s.username, s.password)
+}
+
+// headerTransport adds static headers to every request.
+type headerTransport struct {
+	headers map[string]string
+	base    http.RoundTripper
+}
+
+func (t *headerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// A RoundTripper must not modify the original request.
+	req = req.Clone(req.Context())
+	for name, value := range t.headers {
+		req.Header.Set(name, value)
+	}
+	return t.base.RoundTrip(req)
+}
+
+// retryBackoff is a retryablehttp.Backoff which waits as long as the GitLab instance asks us to
+// with the `Retry-After` or `RateLimit-Reset` headers. Without these headers, it uses an exponential
+// backoff bounded by min and max.
+func retryBackoff(min, max time.Duration, attemptNum int, resp *http.Response) time.Duration {
+	if resp != nil && (resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusServiceUnavailable) {
+		if wait, ok := retryWaitFromHeaders(resp.Header); ok {
+			return wait
+		}
+	}
+
+	wait := math.Pow(2, float64(attemptNum)) * float64(min)
+	if wait > float64(max) {
+		return max
+	}
+	return time.Duration(wait)
+}
+
+// retryWaitFromHeaders returns the time to wait before retrying as indicated by the
+// `Retry-After` (seconds or HTTP date) or `RateLimit-Reset` (unix timestamp) response headers.
+func retryWaitFromHeaders(header http.Header) (time.Duration, bool) {
+	if v := header.Get("Retry-After"); v != "" {
+		if seconds, err := strconv.ParseInt(v, 10, 64); err == nil && seconds >= 0 {
+			return time.Duration(seconds) * time.Second, true
+		}
+		if date, err := http.ParseTime(v); err == nil {
+			return nonNegativeDuration(time.Until(date)), true
+		}
+	}
+
+	if v := header.Get("RateLimit-Reset"); v != "" {
+		if reset, err := strconv.ParseInt(v, 10, 64); err == nil && reset > 0 {
+			return nonNegativeDuration(time.Until(time.Unix(reset, 0))), true
+		}
+	}
+
+	return 0, false
+}
+
+func nonNegativeDuration(d time.Duration) time.Duration {
+	if d < 0 {
+		return 0
+	}
+	return d
+}
+
+// retryMethodContextKey holds the method of a request in its context, because the retry policy only gets the response,
+// which is missing if the request failed without one.
+type retryMethodContextKey struct{}
+
+// retryMethodTransport passes the method of every request to the retry policy, see retryMethodContextKey.
+type retryMethodTransport struct {
+	base http.RoundTripper
+}
+
+func (t *retryMethodTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	return t.base.RoundTrip(req.WithContext(context.WithValue(req.Context(), retryMethodContextKey{}, req.Method)))
+}
+
+// retryPolicy is a retryablehttp.CheckRetry which only retries idempotent requests, so that e.g. a POST request
+// which failed after GitLab created the resource doesn't create it again. Rate limited requests (`429`)
+// are retried for all methods, because GitLab didn't process them.
+func retryPolicy(ctx context.Context, resp *http.Response, err error) (bool, error) {
+	shouldRetry, policyErr := retryablehttp.DefaultRetryPolicy(ctx, resp, err)
+	if !shouldRetry || policyErr != nil {
+		return shouldRetry, policyErr
+	}
+
+	if resp != nil && resp.StatusCode == http.StatusTooManyRequests {
+		return true, nil
+	}
+
+	switch method, _ := ctx.Value(retryMethodContextKey{}).(string); method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true, nil
+	default:
+		return false, nil
+	}
+}
+
+func logRetry(_ retryablehttp.Logger, req *http.Request, attemptNum int) {
+	if attemptNum > 0 {
+		log.Printf("[DEBUG] retrying %s %s (attempt %d)", req.Method, req.URL.Redacted(), attemptNum)
+	}
+}
@@ -0,0 +1,216 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestRetryBackoff_exponential(t *testing.T) {
+	cases := []struct {
+		AttemptNum int
+		Expected   time.Duration
+	}{
+		{AttemptNum: 0, Expected: 1 * time.Second},
+		{AttemptNum: 1, Expected: 2 * time.Second},
+		{AttemptNum: 2, Expected: 4 * time.Second},
+		{AttemptNum: 5, Expected: 10 * time.Second},
+		{AttemptNum: 100, Expected: 10 * time.Second},
+	}
+
+	for _, tc := range cases {
+		resp := &http.Response{StatusCode: http.StatusBadGateway, Header: http.Header{}}
+		if got := retryBackoff(time.Second, 10*time.Second, tc.AttemptNum, resp); got != tc.Expected {
+			t.Fatalf("attempt %d: got %s expected %s", tc.AttemptNum, got, tc.Expected)
+		}
+	}
+}
+
+func TestRetryBackoff_retryAfter(t *testing.T) {
+	resp := &http.Response{StatusCode: http.StatusTooManyRequests, Header: http.Header{}}
+	resp.Header.Set("Retry-After", "42")
+
+	if got := retryBackoff(time.Second, 10*time.Second, 0, resp); got != 42*time.Second {
+		t.Fatalf("got %s expected %s", got, 42*time.Second)
+	}
+}
+
+func TestRetryBackoff_rateLimitReset(t *testing.T) {
+	resp := &http.Response{StatusCode: http.StatusTooManyRequests, Header: http.Header{}}
+	resp.Header.Set("RateLimit-Reset", strconv.FormatInt(time.Now().Add(time.Minute).Unix(), 10))
+
+	got := retryBackoff(time.Second, 10*time.Second, 0, resp)
+	if got <= 50*time.Second || got > time.Minute {
+		t.Fatalf("got %s expected about %s", got, time.Minute)
+	}
+}
+
+func TestRetryBackoff_ignoresHeadersForServerErrors(t *testing.T) {
+	resp := &http.Response{StatusCode: http.StatusInternalServerError, Header: http.Header{}}
+	resp.Header.Set("Retry-After", "42")
+
+	if got := retryBackoff(time.Second, 10*time.Second, 0, resp); got != time.Second {
+		t.Fatalf("got %s expected %s", got, time.Second)
+	}
+}
+
+func TestRetryPolicy(t *testing.T) {
+	cases := []struct {
+		Method     string
+		StatusCode int
+		Err        error
+		Expected   bool
+	}{
+		{Method: http.MethodGet, StatusCode: http.StatusInternalServerError, Expected: true},
+		{Method: http.MethodPut, StatusCode: http.StatusServiceUnavailable, Expected: true},
+		{Method: http.MethodDelete, Err: errors.New("connection reset"), Expected: true},
+		{Method: http.MethodGet, StatusCode: http.StatusNotFound, Expected: false},
+		{Method: http.MethodPost, StatusCode: http.StatusInternalServerError, Expected: false},
+		{Method: http.MethodPost, Err: errors.New("connection reset"), Expected: false},
+		{Method: http.MethodPost, StatusCode: http.StatusTooManyRequests, Expected: true},
+		{Method: http.MethodPatch, StatusCode: http.StatusTooManyRequests, Expected: true},
+	}
+
+	for _, tc := range cases {
+		ctx := context.WithValue(context.Background(), retryMethodContextKey{}, tc.Method)
+		var resp *http.Response
+		if tc.Err == nil {
+			resp = &http.Response{StatusCode: tc.StatusCode, Status: http.StatusText(tc.StatusCode)}
+		}
+
+		if got, _ := retryPolicy(ctx, resp, tc.Err); got != tc.Expected {
+			t.Errorf("%s with status %d and error %v: got %t expected %t", tc.Method, tc.StatusCode, tc.Err, got, tc.Expected)
+		}
+	}
+}
+
+func TestConfigClient_authMethods(t *testing.T) {
+	var gotHeader http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/oauth/token":
+			if err := r.ParseForm(); err != nil {
+				t.Errorf("could not parse token request: %v", err)
+			}
+			w.Header().Set("Content-Type", "application/json")
+			fmt.Fprintf(w, `{"access_token": "%s-token", "token_type": "bearer", "expires_in": 7200}`, r.PostForm.Get("grant_type"))
+		case "/api/v4/user":
+			gotHeader = r.Header.Clone()
+			fmt.Fprint(w, `{"id": 1, "username": "root"}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	cases := []struct {
+		Config         Config
+		ExpectedHeader string
+		ExpectedValue  string
+	}{
+		{
+			Config:         Config{AuthMethod: authMethodToken, Token: "pat"},
+			ExpectedHeader: "Authorization",
+			ExpectedValue:  "Bearer pat",
+		},
+		{
+			Config:         Config{AuthMethod: authMethodJobToken, Token: "job"},
+			ExpectedHeader: "Job-Token",
+			ExpectedValue:  "job",
+		},
+		{
+			Config:         Config{AuthMethod: authMethodPassword, Username: "root", Password: "secret"},
+			ExpectedHeader: "Authorization",
+			ExpectedValue:  "Bearer password-token",
+		},
+		{
+			// The token is requested besides the API transport, thus it's not blocked by the read-only mode.
+			Config:         Config{AuthMethod: authMethodPassword, Username: "root", Password: "secret", ReadOnly: true},
+			ExpectedHeader: "Authorization",
+			ExpectedValue:  "Bearer password-token",
+		},
+		{
+			Config:         Config{AuthMethod: authMethodClientCredentials, ClientID: "id", ClientSecret: "secret"},
+			ExpectedHeader: "Authorization",
+			ExpectedValue:  "Bearer client_credentials-token",
+		},
+	}
+
+	for _, tc := range cases {
+		gotHeader = nil
+		tc.Config.BaseURL = server.URL + "/api/v4/"
+
+		client, err := tc.Config.Client()
+		if err != nil {
+			t.Fatalf("%s: could not create client: %v", tc.Config.AuthMethod, err)
+		}
+
+		// The early auth check is disabled, so this is the first API request.
+		if _, _, err := client.Users.CurrentUser(); err != nil {
+			t.Fatalf("%s: request failed: %v", tc.Config.AuthMethod, err)
+		}
+
+		if got := gotHeader.Get(tc.ExpectedHeader); got != tc.ExpectedValue {
+			t.Fatalf("%s: got %s header %q expected %q", tc.Config.AuthMethod, tc.ExpectedHeader, got, tc.ExpectedValue)
+		}
+	}
+}
+
+func TestConfigClient_proxyAndHeaders(t *testing.T) {
+	var gotRequest *http.Request
+	// The proxy answers the requests itself instead of forwarding them to GitLab.
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotRequest = r
+		fmt.Fprint(w, `{"id": 1, "username": "root"}`)
+	}))
+	t.Cleanup(proxy.Close)
+
+	config := Config{
+		AuthMethod: authMethodToken,
+		Token:      "pat",
+		BaseURL:    "http://gitlab.invalid/api/v4/",
+		ProxyURL:   proxy.URL,
+		Headers:    map[string]string{"X-Proxy-Auth": "secret"},
+	}
+
+	client, err := config.Client()
+	if err != nil {
+		t.Fatalf("could not create client: %v", err)
+	}
+
+	if _, _, err := client.Users.CurrentUser(); err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+
+	if gotRequest == nil {
+		t.Fatalf("request was not sent through the proxy")
+	}
+	if got := gotRequest.URL.String(); got != "http://gitlab.invalid/api/v4/user" {
+		t.Fatalf("proxy got request for %q", got)
+	}
+	if got := gotRequest.Header.Get("X-Proxy-Auth"); got != "secret" {
+		t.Fatalf("header X-Proxy-Auth: got %q expected %q", got, "secret")
+	}
+	if got := gotRequest.Header.Get("Authorization"); got != "Bearer pat" {
+		t.Fatalf("header Authorization: got %q expected %q", got, "Bearer pat")
+	}
+
+	// Hosts in no_proxy are requested directly.
+	gotRequest = nil
+	config.NoProxy = "gitlab.invalid"
+	client, err = config.Client()
+	if err != nil {
+		t.Fatalf("could not create client: %v", err)
+	}
+	if _, _, err := client.Users.CurrentUser(); err == nil {
+		t.Fatalf("expected direct request to unresolvable host to fail")
+	}
+	if gotRequest != nil {
+		t.Fatalf("request to host in no_proxy was sent through the proxy")
+	}
+}
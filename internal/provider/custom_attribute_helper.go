@@ -27,7 +27,7 @@ func CreateCustomAttributeResource(idName string, createGetter CreateGetter, cre
 	}
 
 	readFunc := func(d *schema.ResourceData, meta interface{}) error {
-		client := meta.(*gitlab.Client)
+		client := meta.(*providerMeta).client
 		getter := createGetter(client)
 		log.Printf("[DEBUG] read Custom Attribute %s", d.Id())
 
@@ -46,7 +46,7 @@ func CreateCustomAttributeResource(idName string, createGetter CreateGetter, cre
 	}
 
 	setFunc := func(d *schema.ResourceData, meta interface{}) error {
-		client := meta.(*gitlab.Client)
+		client := meta.(*providerMeta).client
 		setter := createSetter(client)
 
 		id := d.Get(idName).(int)
@@ -67,7 +67,7 @@ func CreateCustomAttributeResource(idName string, createGetter CreateGetter, cre
 	}
 
 	deleteFunc := func(d *schema.ResourceData, meta interface{}) error {
-		client := meta.(*gitlab.Client)
+		client := meta.(*providerMeta).client
 		deleter := createDeleter(client)
 		log.Printf("[DEBUG] delete Custom Attribute %s", d.Id())
 
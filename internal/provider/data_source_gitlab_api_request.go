@@ -0,0 +1,80 @@
+package provider
+
+import (
+	"context"
+	"log"
+	"net/url"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+var _ = registerDataSource("gitlab_api_request", func() *schema.Resource {
+	return &schema.Resource{
+		Description: `The ` + "`gitlab_api_request`" + ` data source allows to send an arbitrary ` + "`GET`" + ` request to the GitLab REST API,
+using the authentication and connection settings of the provider. It is meant as an escape hatch to read information
+for which there is no dedicated data source yet.
+
+For list endpoints, all pages are requested by following the ` + "`X-Next-Page`" + ` response header
+and the items are combined into a single list.
+
+**Upstream API**: [GitLab REST API docs](https://docs.gitlab.com/ee/api/api_resources.html)`,
+
+		ReadContext: dataSourceGitlabAPIRequestRead,
+		Schema: map[string]*schema.Schema{
+			"path": {
+				Description:  "The path of the API endpoint, relative to `/api/v4/`, e.g. `license` or `runners/all`. Path parameters must be URL encoded, e.g. `projects/foo%2Fbar`.",
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"query": {
+				Description: "The query parameters of the request.",
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"max_pages": {
+				Description:  "The maximum number of pages to request from list endpoints. Defaults to `0`, which requests all pages.",
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      0,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"result": {
+				Description: "The JSON encoded response body. Use the `jsondecode()` function to access the response.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+		},
+	}
+})
+
+func dataSourceGitlabAPIRequestRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+
+	path := d.Get("path").(string)
+	query := url.Values{}
+	for k, v := range d.Get("query").(map[string]interface{}) {
+		query.Set(k, v.(string))
+	}
+
+	log.Printf("[DEBUG] read GitLab API %q with query %q", path, query.Encode())
+
+	result, err := gitlabAPIGetAllPages(ctx, client, path, query, d.Get("max_pages").(int))
+	if err != nil {
+		return diag.Errorf("failed to read GitLab API %q: %v", path, err)
+	}
+
+	// NOTE: this data source doesn't have a "real" id, but the same request
+	//       should return the same response, therefore the request is used as id.
+	id := path
+	if len(query) > 0 {
+		id += "?" + query.Encode()
+	}
+	d.SetId(id)
+	d.Set("result", string(result))
+
+	return nil
+}
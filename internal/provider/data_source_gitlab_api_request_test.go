@@ -0,0 +1,73 @@
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccDataSourceGitlabAPIRequest_basic(t *testing.T) {
+	testAccCheck(t)
+	project := testAccCreateProject(t)
+	testAccCreateProject(t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+					data "gitlab_api_request" "project" {
+						path = "projects/%d"
+					}
+
+					data "gitlab_api_request" "projects" {
+						path      = "projects"
+						max_pages = 2
+						query = {
+							owned    = "true"
+							per_page = "1"
+						}
+					}
+				`, project.ID),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckGitlabAPIRequestResult("data.gitlab_api_request.project", func(value string) error {
+						var result struct {
+							PathWithNamespace string `json:"path_with_namespace"`
+						}
+						if err := json.Unmarshal([]byte(value), &result); err != nil {
+							return err
+						}
+						if result.PathWithNamespace != project.PathWithNamespace {
+							return fmt.Errorf("expected project %q, got %q", project.PathWithNamespace, result.PathWithNamespace)
+						}
+						return nil
+					}),
+					testAccCheckGitlabAPIRequestResult("data.gitlab_api_request.projects", func(value string) error {
+						var result []interface{}
+						if err := json.Unmarshal([]byte(value), &result); err != nil {
+							return err
+						}
+						if len(result) != 2 {
+							return fmt.Errorf("expected 2 projects from 2 pages, got %d", len(result))
+						}
+						return nil
+					}),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckGitlabAPIRequestResult(n string, check func(result string) error) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("not found: %s", n)
+		}
+		return check(rs.Primary.Attributes["result"])
+	}
+}
@@ -0,0 +1,75 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+var _ = registerDataSource("gitlab_graphql_query", func() *schema.Resource {
+	return &schema.Resource{
+		Description: `The ` + "`gitlab_graphql_query`" + ` data source allows to run an arbitrary query against the GitLab GraphQL API,
+using the authentication and connection settings of the provider. It is meant to read information which is only
+available in the GraphQL API, like compliance frameworks or security policies.
+
+-> Only queries are allowed, mutations and subscriptions are rejected.
+
+**Upstream API**: [GitLab GraphQL API docs](https://docs.gitlab.com/ee/api/graphql/reference/)`,
+
+		ReadContext: dataSourceGitlabGraphQLQueryRead,
+		Schema: map[string]*schema.Schema{
+			"query": {
+				Description:  "The GraphQL query.",
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"variables": {
+				Description:  "The JSON encoded variables of the query. Use the `jsonencode()` function to build them.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsJSON,
+			},
+			"result": {
+				Description: "The JSON encoded `data` of the response. Use the `jsondecode()` function to access it.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+		},
+	}
+})
+
+func dataSourceGitlabGraphQLQueryRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	graphql := meta.(*providerMeta).graphql
+
+	query := d.Get("query").(string)
+	if isGraphQLMutation(query) {
+		return diag.Errorf("the query must not contain mutations or subscriptions")
+	}
+
+	var variables map[string]interface{}
+	if v, ok := d.GetOk("variables"); ok {
+		if err := json.Unmarshal([]byte(v.(string)), &variables); err != nil {
+			return diag.Errorf("variables must be a JSON object: %v", err)
+		}
+	}
+
+	log.Printf("[DEBUG] run GitLab GraphQL query %q", query)
+
+	result, err := graphql.Query(ctx, query, variables)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// NOTE: this data source doesn't have a "real" id, but the same query
+	//       should return the same response, therefore its hash is used as id.
+	d.SetId(fmt.Sprintf("%d", schema.HashString(query+d.Get("variables").(string))))
+	d.Set("result", string(result))
+
+	return nil
+}
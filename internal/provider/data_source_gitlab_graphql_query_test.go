@@ -0,0 +1,111 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestDataSourceGitlabGraphQLQuery_readOnly(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			t.Errorf("expected only GET requests with a read-only provider, got %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		switch r.URL.Path {
+		case "/api/v4/user":
+			fmt.Fprint(w, `{"id": 1, "username": "root"}`)
+		case "/api/graphql":
+			fmt.Fprint(w, `{"data": {"currentUser": {"username": "root"}}}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	provider := New("dev")()
+	diags := provider.Configure(context.Background(), terraform.NewResourceConfigRaw(map[string]interface{}{
+		"token":     "pat",
+		"base_url":  server.URL + "/api/v4/",
+		"read_only": true,
+	}))
+	if diags.HasError() {
+		t.Fatalf("could not configure provider: %v", diags)
+	}
+
+	dataSource := provider.DataSourcesMap["gitlab_graphql_query"]
+	d := schema.TestResourceDataRaw(t, dataSource.Schema, map[string]interface{}{
+		"query": "query { currentUser { username } }",
+	})
+	if diags := dataSource.ReadContext(context.Background(), d, provider.Meta()); diags.HasError() {
+		t.Fatalf("could not run query with a read-only provider: %v", diags)
+	}
+	if expected := `{"currentUser": {"username": "root"}}`; d.Get("result").(string) != expected {
+		t.Fatalf("got result %s expected %s", d.Get("result"), expected)
+	}
+}
+
+func TestAccDataSourceGitlabGraphQLQuery_basic(t *testing.T) {
+	testAccCheck(t)
+	project := testAccCreateProject(t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+					data "gitlab_graphql_query" "project" {
+						query = <<-EOT
+							query($fullPath: ID!) {
+								project(fullPath: $fullPath) {
+									name
+								}
+							}
+						EOT
+
+						variables = jsonencode({
+							fullPath = "%s"
+						})
+					}
+				`, project.PathWithNamespace),
+				Check: func(s *terraform.State) error {
+					rs, ok := s.RootModule().Resources["data.gitlab_graphql_query.project"]
+					if !ok {
+						return fmt.Errorf("not found: data.gitlab_graphql_query.project")
+					}
+
+					var result struct {
+						Project struct {
+							Name string `json:"name"`
+						} `json:"project"`
+					}
+					if err := json.Unmarshal([]byte(rs.Primary.Attributes["result"]), &result); err != nil {
+						return err
+					}
+					if result.Project.Name != project.Name {
+						return fmt.Errorf("expected project %q, got %q", project.Name, result.Project.Name)
+					}
+					return nil
+				},
+			},
+			{
+				Config: `
+					data "gitlab_graphql_query" "mutation" {
+						query = "mutation { createSnippet(input: {title: \"foo\"}) { errors } }"
+					}
+				`,
+				ExpectError: regexp.MustCompile("must not contain mutations"),
+			},
+		},
+	})
+}
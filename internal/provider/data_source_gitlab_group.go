@@ -102,7 +102,7 @@ var _ = registerDataSource("gitlab_group", func() *schema.Resource {
 })
 
 func dataSourceGitlabGroupRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 
 	var group *gitlab.Group
 	var err error
@@ -100,7 +100,7 @@ var _ = registerDataSource("gitlab_group_membership", func() *schema.Resource {
 })
 
 func dataSourceGitlabGroupMembershipRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 
 	var group *gitlab.Group
 	var err error
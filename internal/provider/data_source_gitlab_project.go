@@ -221,7 +221,7 @@ var _ = registerDataSource("gitlab_project", func() *schema.Resource {
 })
 
 func dataSourceGitlabProjectRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 
 	log.Printf("[INFO] Reading Gitlab project")
 
@@ -214,7 +214,7 @@ var _ = registerDataSource("gitlab_project_issues", func() *schema.Resource {
 })
 
 func dataSourceGitlabProjectIssuesRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 
 	project := d.Get("project").(string)
 	options := gitlab.ListProjectIssuesOptions{
@@ -176,7 +176,7 @@ var _ = registerDataSource("gitlab_user", func() *schema.Resource {
 })
 
 func dataSourceGitlabUserRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 
 	var user *gitlab.User
 	var err error
@@ -197,28 +197,28 @@ func dataSourceGitlabUserRead(ctx context.Context, d *schema.ResourceData, meta
 		username := strings.ToLower(usernameData.(string))
 		email := strings.ToLower(emailData.(string))
 
-		listUsersOptions := &gitlab.ListUsersOptions{}
 		if usernameOk {
-			// Get user by username
-			listUsersOptions.Username = gitlab.String(username)
+			// Get user by username, users looked up by other data sources are cached
+			user, err = meta.(*providerMeta).cache.User(ctx, username)
+			if err != nil {
+				return diag.FromErr(err)
+			}
 		} else {
 			// Get user by email
-			listUsersOptions.Search = gitlab.String(email)
-		}
+			var users []*gitlab.User
+			users, _, err = client.Users.ListUsers(&gitlab.ListUsersOptions{Search: gitlab.String(email)}, gitlab.WithContext(ctx))
+			if err != nil {
+				return diag.FromErr(err)
+			}
 
-		var users []*gitlab.User
-		users, _, err = client.Users.ListUsers(listUsersOptions, gitlab.WithContext(ctx))
-		if err != nil {
-			return diag.FromErr(err)
-		}
+			if len(users) == 0 {
+				return diag.Errorf("couldn't find a user matching: %s", email)
+			} else if len(users) != 1 {
+				return diag.Errorf("more than one user found matching: %s", email)
+			}
 
-		if len(users) == 0 {
-			return diag.Errorf("couldn't find a user matching: %s%s", username, email)
-		} else if len(users) != 1 {
-			return diag.Errorf("more than one user found matching: %s%s", username, email)
+			user = users[0]
 		}
-
-		user = users[0]
 	} else {
 		return diag.Errorf("one and only one of user_id, username or email must be set")
 	}
@@ -0,0 +1,131 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	retryablehttp "github.com/hashicorp/go-retryablehttp"
+	gitlab "github.com/xanzy/go-gitlab"
+)
+
+// graphQLClient sends requests to the GitLab GraphQL API.
+// The requests are sent with the REST client, so that they use the same authentication,
+// TLS, proxy and retry settings as all other requests of the provider.
+type graphQLClient struct {
+	client *gitlab.Client
+}
+
+func newGraphQLClient(client *gitlab.Client) *graphQLClient {
+	return &graphQLClient{client: client}
+}
+
+// graphQLError is an error reported in the `errors` of a GraphQL response.
+type graphQLError struct {
+	Message string `json:"message"`
+}
+
+type graphQLResponse struct {
+	Data   json.RawMessage `json:"data"`
+	Errors []graphQLError  `json:"errors"`
+}
+
+// Query runs the given GraphQL query with the given variables and returns the `data` of the response.
+// The query is sent as GET request, which GitLab only accepts for queries and not for mutations,
+// so that queries also work if the provider is read-only.
+func (c *graphQLClient) Query(ctx context.Context, query string, variables map[string]interface{}) (json.RawMessage, error) {
+	params := url.Values{"query": {query}}
+	if variables != nil {
+		encoded, err := json.Marshal(variables)
+		if err != nil {
+			return nil, err
+		}
+		params.Set("variables", string(encoded))
+	}
+
+	// The REST client can only build requests relative to the `/api/v4/` base URL,
+	// thus the URL is replaced with the GraphQL endpoint after the request is built.
+	graphQLPath := strings.TrimSuffix(strings.TrimSuffix(c.client.BaseURL().Path, "/"), "/api/v4") + "/api/graphql"
+	withGraphQLURL := func(req *retryablehttp.Request) error {
+		req.URL.Path = graphQLPath
+		req.URL.RawPath = ""
+		req.URL.RawQuery = params.Encode()
+		return nil
+	}
+
+	req, err := c.client.NewRequest(http.MethodGet, "", nil, []gitlab.RequestOptionFunc{gitlab.WithContext(ctx), withGraphQLURL})
+	if err != nil {
+		return nil, err
+	}
+
+	var resp graphQLResponse
+	if _, err := c.client.Do(req, &resp); err != nil {
+		return nil, err
+	}
+
+	if len(resp.Errors) > 0 {
+		messages := make([]string, 0, len(resp.Errors))
+		for _, e := range resp.Errors {
+			messages = append(messages, e.Message)
+		}
+		return nil, fmt.Errorf("GraphQL request failed: %s", strings.Join(messages, "; "))
+	}
+
+	return resp.Data, nil
+}
+
+// isGraphQLMutation returns true if the GraphQL document contains a mutation (or subscription) operation.
+// It only looks at the top level of the document, where the operation type keywords are.
+// This is a best effort check of the `gitlab_graphql_query` data source, GitLab rejects mutations sent as GET request anyway.
+func isGraphQLMutation(query string) bool {
+	depth := 0
+	for i := 0; i < len(query); i++ {
+		switch c := query[i]; {
+		case c == '#':
+			// Skip comments until the end of the line.
+			for i < len(query) && query[i] != '\n' {
+				i++
+			}
+		case strings.HasPrefix(query[i:], `"""`):
+			// Skip block strings, which may contain unescaped quotes and escaped triple quotes.
+			for i += 3; i < len(query) && !strings.HasPrefix(query[i:], `"""`); i++ {
+				if strings.HasPrefix(query[i:], `\"""`) {
+					i += 3
+				}
+			}
+			if i >= len(query) {
+				// An unterminated block string is invalid, err on the side of caution.
+				return true
+			}
+			i += 2
+		case c == '"':
+			// Skip strings, e.g. in default values of variables.
+			for i++; i < len(query) && query[i] != '"'; i++ {
+				if query[i] == '\\' {
+					i++
+				}
+			}
+		case c == '{' || c == '(':
+			depth++
+		case c == '}' || c == ')':
+			depth--
+		case depth == 0 && isGraphQLNameStart(c):
+			start := i
+			for i < len(query) && (isGraphQLNameStart(query[i]) || (query[i] >= '0' && query[i] <= '9')) {
+				i++
+			}
+			if name := query[start:i]; name == "mutation" || name == "subscription" {
+				return true
+			}
+			i--
+		}
+	}
+	return false
+}
+
+func isGraphQLNameStart(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
@@ -0,0 +1,95 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGraphQLClient_Query(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/graphql" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if got := r.Header.Get("Authorization"); got != "Bearer pat" {
+			t.Errorf("expected the token of the provider to be sent, got %q", got)
+		}
+
+		if r.Method != http.MethodGet {
+			t.Errorf("expected the query to be sent as GET request, got %s", r.Method)
+		}
+
+		var variables map[string]interface{}
+		if err := json.Unmarshal([]byte(r.URL.Query().Get("variables")), &variables); err != nil {
+			t.Errorf("could not decode variables: %v", err)
+		}
+
+		if variables["fullPath"] == "unknown" {
+			fmt.Fprint(w, `{"data": null, "errors": [{"message": "not found"}, {"message": "really not found"}]}`)
+			return
+		}
+		fmt.Fprintf(w, `{"data": {"project": {"fullPath": %q}}}`, variables["fullPath"])
+	}))
+	t.Cleanup(server.Close)
+
+	config := Config{AuthMethod: authMethodToken, Token: "pat", BaseURL: server.URL + "/api/v4/"}
+	client, err := config.Client()
+	if err != nil {
+		t.Fatalf("could not create client: %v", err)
+	}
+	graphql := newGraphQLClient(client)
+
+	const query = `query($fullPath: ID!) { project(fullPath: $fullPath) { fullPath } }`
+
+	data, err := graphql.Query(context.Background(), query, map[string]interface{}{"fullPath": "foo/bar"})
+	if err != nil {
+		t.Fatalf("query failed: %v", err)
+	}
+	if expected := `{"project": {"fullPath": "foo/bar"}}`; string(data) != expected {
+		t.Fatalf("got data %s expected %s", data, expected)
+	}
+
+	_, err = graphql.Query(context.Background(), query, map[string]interface{}{"fullPath": "unknown"})
+	if err == nil || err.Error() != "GraphQL request failed: not found; really not found" {
+		t.Fatalf("expected the GraphQL errors to be returned, got: %v", err)
+	}
+
+	// Queries are sent as GET requests, thus they also work if the provider is read-only.
+	readOnlyConfig := config
+	readOnlyConfig.ReadOnly = true
+	readOnlyClient, err := readOnlyConfig.Client()
+	if err != nil {
+		t.Fatalf("could not create client: %v", err)
+	}
+	if _, err := newGraphQLClient(readOnlyClient).Query(context.Background(), query, map[string]interface{}{"fullPath": "foo/bar"}); err != nil {
+		t.Fatalf("expected the query to work with a read-only client, got: %v", err)
+	}
+}
+
+func TestIsGraphQLMutation(t *testing.T) {
+	cases := []struct {
+		Query    string
+		Expected bool
+	}{
+		{Query: `{ currentUser { username } }`, Expected: false},
+		{Query: `query { mutation: currentUser { username } }`, Expected: false},
+		{Query: `query($path: ID! = "mutation") { project(fullPath: $path) { id } }`, Expected: false},
+		{Query: "# mutation\nquery { currentUser { username } }", Expected: false},
+		{Query: `mutation { createSnippet(input: {title: "foo"}) { errors } }`, Expected: true},
+		{Query: `query A { currentUser { username } } mutation B { createSnippet(input: {title: "foo"}) { errors } }`, Expected: true},
+		{Query: `query { project(fullPath: """ " } mutation { x """) { id } }`, Expected: false},
+		{Query: `query { project(fullPath: """ \""" """) { id } } mutation { createSnippet(input: {title: "foo"}) { errors } }`, Expected: true},
+		{Query: `query { project(fullPath: """ " } mutation { x }`, Expected: true},
+		{Query: `subscription { issueUpdated(issuableId: "1") { id } }`, Expected: true},
+	}
+
+	for _, tc := range cases {
+		if got := isGraphQLMutation(tc.Query); got != tc.Expected {
+			t.Fatalf("%q: got %t expected %t", tc.Query, got, tc.Expected)
+		}
+	}
+}
@@ -86,6 +86,20 @@ func testAccCheckEE(t *testing.T) {
 	}
 }
 
+// testAccCheckGitLabVersionAtLeast is a test helper that skips the current test if the GitLab version is older than wantVersion.
+func testAccCheckGitLabVersionAtLeast(t *testing.T, wantVersion string) {
+	t.Helper()
+
+	isAtLeast, err := isGitLabVersionAtLeast(testGitlabClient, wantVersion)()
+	if err != nil {
+		t.Fatalf("could not check GitLab version: %v", err)
+	}
+
+	if !isAtLeast {
+		t.Skipf("Test is skipped for GitLab versions older than %s", wantVersion)
+	}
+}
+
 // testAccCurrentUser is a test helper for getting the current user of the provided client.
 func testAccCurrentUser(t *testing.T) *gitlab.User {
 	t.Helper()
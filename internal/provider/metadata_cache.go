@@ -0,0 +1,89 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+
+	"github.com/xanzy/go-gitlab"
+)
+
+// metadataCache caches GitLab instance metadata which is not expected to change
+// during a single Terraform run, like the GitLab version and the users looked up by username.
+// It is part of the providerMeta and thus shared by all resources and data sources of a configured provider,
+// so that e.g. reading many projects doesn't fetch the GitLab version over and over again.
+//
+// The lock only guards the cached values, it's not held while fetching them.
+// Concurrent lookups of the same uncached value may thus fetch it more than once.
+type metadataCache struct {
+	client *gitlab.Client
+
+	mu      sync.Mutex
+	version *gitlab.Version
+	users   map[string]*gitlab.User
+}
+
+func newMetadataCache(client *gitlab.Client) *metadataCache {
+	return &metadataCache{
+		client: client,
+		users:  make(map[string]*gitlab.User),
+	}
+}
+
+// Version returns the version of the GitLab instance.
+func (c *metadataCache) Version() (*gitlab.Version, error) {
+	c.mu.Lock()
+	version := c.version
+	c.mu.Unlock()
+	if version != nil {
+		return version, nil
+	}
+
+	log.Printf("[DEBUG] fetch GitLab version")
+	version, _, err := c.client.Version.GetVersion()
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	c.version = version
+	c.mu.Unlock()
+
+	return version, nil
+}
+
+// User returns the user with the given username. Usernames are case-insensitive.
+func (c *metadataCache) User(ctx context.Context, username string) (*gitlab.User, error) {
+	username = strings.ToLower(username)
+
+	c.mu.Lock()
+	user, ok := c.users[username]
+	c.mu.Unlock()
+	if ok {
+		return user, nil
+	}
+
+	log.Printf("[DEBUG] fetch GitLab user %q", username)
+	users, _, err := c.client.Users.ListUsers(&gitlab.ListUsersOptions{Username: gitlab.String(username)}, gitlab.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	if len(users) == 0 {
+		return nil, fmt.Errorf("couldn't find a user matching: %s", username)
+	} else if len(users) != 1 {
+		return nil, fmt.Errorf("more than one user found matching: %s", username)
+	}
+
+	c.addUser(users[0])
+
+	return users[0], nil
+}
+
+// addUser adds an already fetched user, e.g. the one the provider is authenticated as, to the cache.
+func (c *metadataCache) addUser(user *gitlab.User) {
+	c.mu.Lock()
+	c.users[strings.ToLower(user.Username)] = user
+	c.mu.Unlock()
+}
@@ -0,0 +1,59 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/xanzy/go-gitlab"
+)
+
+func TestMetadataCache_fetchesOnce(t *testing.T) {
+	requests := make(map[string]int)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests[r.URL.Path]++
+		switch r.URL.Path {
+		case "/api/v4/version":
+			fmt.Fprint(w, `{"version": "14.8.2-ee", "revision": "abc"}`)
+		case "/api/v4/users":
+			if username := r.URL.Query().Get("username"); username != "jane" {
+				t.Errorf("expected the lowercase username to be looked up, got %q", username)
+			}
+			fmt.Fprint(w, `[{"id": 7, "username": "jane"}]`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := gitlab.NewClient("token", gitlab.WithBaseURL(server.URL+"/api/v4/"))
+	if err != nil {
+		t.Fatalf("could not create client: %v", err)
+	}
+
+	meta := newProviderMeta(client)
+	// The user the provider is authenticated as is added by the early auth check.
+	meta.cache.addUser(&gitlab.User{ID: 1, Username: "Root"})
+
+	for i := 0; i < 3; i++ {
+		isAtLeast, err := meta.isGitLabVersionAtLeast("14.1")
+		if err != nil || !isAtLeast {
+			t.Fatalf("expected version to be at least 14.1, got %v (err: %v)", isAtLeast, err)
+		}
+
+		for username, id := range map[string]int{"jane": 7, "Jane": 7, "root": 1} {
+			user, err := meta.cache.User(context.Background(), username)
+			if err != nil || user.ID != id {
+				t.Fatalf("expected user %q to have ID %d, got %v (err: %v)", username, id, user, err)
+			}
+		}
+	}
+
+	for path, n := range requests {
+		if n != 1 {
+			t.Fatalf("expected a single request to %s, got %d", path, n)
+		}
+	}
+}
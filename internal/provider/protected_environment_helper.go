@@ -0,0 +1,186 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	gitlab "github.com/xanzy/go-gitlab"
+)
+
+// protectedEnvironment is a protected environment of a project or group,
+// including the attributes which aren't supported by the GitLab client yet.
+type protectedEnvironment struct {
+	Name                  string                                 `json:"name"`
+	DeployAccessLevels    []*gitlab.EnvironmentAccessDescription `json:"deploy_access_levels"`
+	RequiredApprovalCount int                                    `json:"required_approval_count"`
+}
+
+// protectEnvironmentOptions are the options to protect an environment of a project or group.
+type protectEnvironmentOptions struct {
+	Name                  *string                            `json:"name"`
+	DeployAccessLevels    []*gitlab.EnvironmentAccessOptions `json:"deploy_access_levels"`
+	RequiredApprovalCount *int                               `json:"required_approval_count,omitempty"`
+}
+
+// protectedEnvironmentSchema returns the schema of the attributes shared by the protected environment
+// resources of projects and groups. All attributes force a new resource, because protected environments
+// can't be updated in all supported GitLab versions.
+func protectedEnvironmentSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"required_approval_count": {
+			Description:  "The number of approvals required to deploy to this environment.",
+			Type:         schema.TypeInt,
+			Optional:     true,
+			ForceNew:     true,
+			ValidateFunc: validation.IntAtLeast(0),
+		},
+		"deploy_access_levels": {
+			Description: "The users, groups or access levels allowed to deploy to this environment. Each entry must have exactly one of `access_level`, `user_id` or `group_id`.",
+			Type:        schema.TypeSet,
+			Required:    true,
+			ForceNew:    true,
+			MinItems:    1,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"access_level": {
+						Description:      fmt.Sprintf("Level of access required to deploy to this environment. Valid values are: %s.", renderValueListForDocs(validProtectedEnvironmentDeploymentLevelNames)),
+						Type:             schema.TypeString,
+						Optional:         true,
+						ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice(validProtectedEnvironmentDeploymentLevelNames, false)),
+					},
+					"access_level_description": {
+						Description: "Readable description of level of access.",
+						Type:        schema.TypeString,
+						Computed:    true,
+					},
+					"user_id": {
+						Description: "The ID of the user allowed to deploy to this environment.",
+						Type:        schema.TypeInt,
+						Optional:    true,
+					},
+					"group_id": {
+						Description: "The ID of the group allowed to deploy to this environment.",
+						Type:        schema.TypeInt,
+						Optional:    true,
+					},
+				},
+			},
+		},
+	}
+}
+
+// expandProtectEnvironmentOptions returns the options to protect the given environment from the configuration.
+func expandProtectEnvironmentOptions(d *schema.ResourceData, environment string) *protectEnvironmentOptions {
+	options := &protectEnvironmentOptions{
+		Name: gitlab.String(environment),
+	}
+
+	for _, v := range d.Get("deploy_access_levels").(*schema.Set).List() {
+		deployAccessLevel := v.(map[string]interface{})
+		opt := &gitlab.EnvironmentAccessOptions{}
+
+		if accessLevel := deployAccessLevel["access_level"].(string); accessLevel != "" {
+			opt.AccessLevel = gitlab.AccessLevel(accessLevelNameToValue[accessLevel])
+		}
+		if userID := deployAccessLevel["user_id"].(int); userID != 0 {
+			opt.UserID = gitlab.Int(userID)
+		}
+		if groupID := deployAccessLevel["group_id"].(int); groupID != 0 {
+			opt.GroupID = gitlab.Int(groupID)
+		}
+		options.DeployAccessLevels = append(options.DeployAccessLevels, opt)
+	}
+
+	if v, ok := d.GetOk("required_approval_count"); ok {
+		options.RequiredApprovalCount = gitlab.Int(v.(int))
+	}
+
+	return options
+}
+
+// customizeDiffProtectedEnvironmentDeployAccessLevels validates that each of the configured
+// `deploy_access_levels` has exactly one of `access_level`, `user_id` or `group_id`.
+func customizeDiffProtectedEnvironmentDeployAccessLevels(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	config := d.GetRawConfig()
+	if config.IsNull() || !config.IsKnown() {
+		return nil
+	}
+
+	deployAccessLevels := config.GetAttr("deploy_access_levels")
+	if deployAccessLevels.IsNull() || !deployAccessLevels.IsKnown() {
+		return nil
+	}
+
+	for it := deployAccessLevels.ElementIterator(); it.Next(); {
+		_, deployAccessLevel := it.Element()
+		if !deployAccessLevel.IsKnown() {
+			continue
+		}
+
+		// Unknown values count as set, because they are known to be configured.
+		set := 0
+		for _, attr := range []string{"access_level", "user_id", "group_id"} {
+			if !deployAccessLevel.GetAttr(attr).IsNull() {
+				set++
+			}
+		}
+		if set != 1 {
+			return fmt.Errorf("each of `deploy_access_levels` must have exactly one of `access_level`, `user_id` or `group_id`")
+		}
+	}
+	return nil
+}
+
+// flattenEnvironmentAccessDescriptions flattens the deploy access levels of a protected environment for the tf state.
+// The access level of users and groups is omitted, because it's not configurable.
+func flattenEnvironmentAccessDescriptions(descriptions []*gitlab.EnvironmentAccessDescription) (values []map[string]interface{}) {
+	for _, description := range descriptions {
+		accessLevel := ""
+		if description.UserID == 0 && description.GroupID == 0 {
+			accessLevel = accessLevelValueToName[description.AccessLevel]
+		}
+
+		values = append(values, map[string]interface{}{
+			"access_level":             accessLevel,
+			"access_level_description": description.AccessLevelDescription,
+			"user_id":                  description.UserID,
+			"group_id":                 description.GroupID,
+		})
+	}
+
+	return values
+}
+
+// protectedEnvironmentsPath returns the API path of the protected environments of a project or group,
+// e.g. `projects/42/protected_environments`. The given kind is either `projects` or `groups`.
+func protectedEnvironmentsPath(kind string, id string) string {
+	return fmt.Sprintf("%s/%s/protected_environments", kind, url.PathEscape(id))
+}
+
+func createProtectedEnvironment(ctx context.Context, client *gitlab.Client, path string, options *protectEnvironmentOptions) error {
+	_, _, err := gitlabAPIRequest(ctx, client, http.MethodPost, path, nil, options)
+	return err
+}
+
+func getProtectedEnvironment(ctx context.Context, client *gitlab.Client, path string, environment string) (*protectedEnvironment, error) {
+	result, _, err := gitlabAPIRequest(ctx, client, http.MethodGet, fmt.Sprintf("%s/%s", path, url.PathEscape(environment)), nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var protected protectedEnvironment
+	if err := json.Unmarshal(result, &protected); err != nil {
+		return nil, fmt.Errorf("failed to decode protected environment %q: %w", environment, err)
+	}
+	return &protected, nil
+}
+
+func deleteProtectedEnvironment(ctx context.Context, client *gitlab.Client, path string, environment string) error {
+	_, _, err := gitlabAPIRequest(ctx, client, http.MethodDelete, fmt.Sprintf("%s/%s", path, url.PathEscape(environment)), nil, nil)
+	return err
+}
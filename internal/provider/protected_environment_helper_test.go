@@ -0,0 +1,23 @@
+package provider
+
+import (
+	"reflect"
+	"testing"
+
+	gitlab "github.com/xanzy/go-gitlab"
+)
+
+func TestFlattenEnvironmentAccessDescriptions(t *testing.T) {
+	values := flattenEnvironmentAccessDescriptions([]*gitlab.EnvironmentAccessDescription{
+		{AccessLevel: gitlab.DeveloperPermissions, AccessLevelDescription: "Developers + Maintainers"},
+		{AccessLevel: gitlab.MaintainerPermissions, AccessLevelDescription: "Jane", UserID: 42},
+	})
+
+	expected := []map[string]interface{}{
+		{"access_level": "developer", "access_level_description": "Developers + Maintainers", "user_id": 0, "group_id": 0},
+		{"access_level": "", "access_level_description": "Jane", "user_id": 42, "group_id": 0},
+	}
+	if !reflect.DeepEqual(values, expected) {
+		t.Fatalf("got %v, expected %v", values, expected)
+	}
+}
@@ -3,10 +3,13 @@ package provider
 import (
 	"context"
 	"fmt"
+	"os"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 var (
@@ -34,9 +37,42 @@ func New(version string) func() *schema.Provider {
 			Schema: map[string]*schema.Schema{
 				"token": {
 					Type:        schema.TypeString,
-					Required:    true,
+					Optional:    true,
 					DefaultFunc: schema.EnvDefaultFunc("GITLAB_TOKEN", nil),
-					Description: "The OAuth2 Token, Project, Group, Personal Access Token or CI Job Token used to connect to GitLab. The OAuth method is used in this provider for authentication (using Bearer authorization token). See https://docs.gitlab.com/ee/api/#authentication for details. It may be sourced from the `GITLAB_TOKEN` environment variable.",
+					Description: "The OAuth2 Token, Project, Group, Personal Access Token or CI Job Token used to connect to GitLab. The OAuth method is used in this provider for authentication (using Bearer authorization token). See https://docs.gitlab.com/ee/api/#authentication for details. It may be sourced from the `GITLAB_TOKEN` environment variable. Required when `auth_method` is `token`. When `auth_method` is `job_token` it defaults to the `CI_JOB_TOKEN` environment variable.",
+				},
+				"auth_method": {
+					Type:         schema.TypeString,
+					Optional:     true,
+					DefaultFunc:  schema.EnvDefaultFunc("GITLAB_AUTH_METHOD", authMethodToken),
+					Description:  fmt.Sprintf("The method used to authenticate with GitLab. `token` sends `token` as Bearer token. `job_token` sends `token` as CI job token in the `JOB-TOKEN` header, which allows to use the provider in GitLab CI pipelines. `password` requests an OAuth2 token using `username` and `password` (password grant). `client_credentials` requests an OAuth2 token for the application identified by `client_id` and `client_secret` (client credentials grant). OAuth2 tokens are refreshed automatically when they expire. Valid values are %s. It may be sourced from the `GITLAB_AUTH_METHOD` environment variable. Defaults to `token`.", renderValueListForDocs(validAuthMethods)),
+					ValidateFunc: validation.StringInSlice(validAuthMethods, false),
+				},
+				"username": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					DefaultFunc: schema.EnvDefaultFunc("GITLAB_USERNAME", nil),
+					Description: "The username used to authenticate when `auth_method` is `password`. It may be sourced from the `GITLAB_USERNAME` environment variable.",
+				},
+				"password": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					Sensitive:   true,
+					DefaultFunc: schema.EnvDefaultFunc("GITLAB_PASSWORD", nil),
+					Description: "The password used to authenticate when `auth_method` is `password`. It may be sourced from the `GITLAB_PASSWORD` environment variable.",
+				},
+				"client_id": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					DefaultFunc: schema.EnvDefaultFunc("GITLAB_CLIENT_ID", nil),
+					Description: "The OAuth2 application ID used to authenticate when `auth_method` is `client_credentials`. It may be sourced from the `GITLAB_CLIENT_ID` environment variable.",
+				},
+				"client_secret": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					Sensitive:   true,
+					DefaultFunc: schema.EnvDefaultFunc("GITLAB_CLIENT_SECRET", nil),
+					Description: "The OAuth2 application secret used to authenticate when `auth_method` is `client_credentials`. It may be sourced from the `GITLAB_CLIENT_SECRET` environment variable.",
 				},
 				"base_url": {
 					Type:        schema.TypeString,
@@ -75,12 +111,86 @@ func New(version string) func() *schema.Provider {
 					Default:     "",
 					Description: "File path to client key when GitLab instance is behind company proxy. File must contain PEM encoded data. Required when `client_cert` is set.",
 				},
+				"proxy_url": {
+					Type:         schema.TypeString,
+					Optional:     true,
+					Description:  "The URL of the proxy to send all requests to GitLab through, e.g. `http://proxy.example.com:3128`. The `http`, `https` and `socks5` schemes are supported. By default, the proxy is taken from the `HTTPS_PROXY` and `HTTP_PROXY` environment variables. The proxy works together with `client_cert` and `client_key`, the client certificate is presented to GitLab through the proxy tunnel.",
+					ValidateFunc: validation.IsURLWithScheme([]string{"http", "https", "socks5"}),
+				},
+				"no_proxy": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					Description: "A comma-separated list of hosts, domains and IP ranges which are requested without the proxy, in the same format as the `NO_PROXY` environment variable, which it overrides.",
+				},
+				"headers": {
+					Type:        schema.TypeMap,
+					Optional:    true,
+					Sensitive:   true,
+					Elem:        &schema.Schema{Type: schema.TypeString},
+					Description: "Additional HTTP headers to send with every request to GitLab, e.g. to authenticate against an identity-aware proxy in front of GitLab. The headers are not written to the debug logs.",
+				},
+				"read_only": {
+					Type:        schema.TypeBool,
+					Optional:    true,
+					DefaultFunc: schema.EnvDefaultFunc("GITLAB_READ_ONLY", false),
+					Description: "When set to true, the provider only sends requests which don't change data in GitLab and fails every resource which tries to create, update or delete something. GraphQL queries, e.g. of the `gitlab_graphql_query` data source, are sent as GET requests and still work. This makes it safe to run `terraform plan` with a privileged token, e.g. in merge request pipelines. It may be sourced from the `GITLAB_READ_ONLY` environment variable.",
+				},
 				"early_auth_check": {
 					Type:        schema.TypeBool,
 					Optional:    true,
 					Default:     true,
 					Description: "(Experimental) By default the provider does a dummy request to get the current user in order to verify that the provider configuration is correct and the GitLab API is reachable. Turn it off, to skip this check. This may be useful if the GitLab instance does not yet exist and is created within the same terraform module. This is an experimental feature and may change in the future. Please make sure to always keep backups of your state.",
 				},
+				"max_retries": {
+					Type:         schema.TypeInt,
+					Optional:     true,
+					DefaultFunc:  schema.EnvDefaultFunc("GITLAB_MAX_RETRIES", 5),
+					Description:  "The maximum number of times a request is retried when GitLab responds with a rate limit (`429`) or server (`5xx`) error. Only rate limited requests are retried for all methods, other errors are only retried for idempotent requests, e.g. not for `POST` requests. Set to `0` to disable retries. It may be sourced from the `GITLAB_MAX_RETRIES` environment variable. Defaults to `5`.",
+					ValidateFunc: validation.IntAtLeast(0),
+				},
+				"min_backoff": {
+					Type:         schema.TypeInt,
+					Optional:     true,
+					Default:      1,
+					Description:  "The minimum time in seconds to wait between retries. The wait time doubles with every retry, up to `max_backoff`. The `Retry-After` and `RateLimit-Reset` response headers take precedence if GitLab sends them. Must be at least `1` and at most `max_backoff`. Defaults to `1`.",
+					ValidateFunc: validation.IntAtLeast(1),
+				},
+				"max_backoff": {
+					Type:         schema.TypeInt,
+					Optional:     true,
+					Default:      30,
+					Description:  "The maximum time in seconds to wait between retries. Defaults to `30`.",
+					ValidateFunc: validation.IntAtLeast(1),
+				},
+				"requests_per_second": {
+					Type:         schema.TypeFloat,
+					Optional:     true,
+					DefaultFunc:  schema.EnvDefaultFunc("GITLAB_REQUESTS_PER_SECOND", 0.0),
+					Description:  "Limits the number of requests the provider sends to GitLab per second. By default, the limit is derived from the `RateLimit-Limit` header GitLab sends, if any. It may be sourced from the `GITLAB_REQUESTS_PER_SECOND` environment variable.",
+					ValidateFunc: validation.FloatAtLeast(0),
+				},
+				"resource_defaults": {
+					Type:        schema.TypeList,
+					Optional:    true,
+					MaxItems:    1,
+					Description: "Defaults which are applied to all resources of a type, similar to `default_tags` in other providers. The merged values are exposed in dedicated attributes (e.g. `tags_all` of `gitlab_project`), so that changes to the defaults show up in the plan.",
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"project_tags": {
+								Type:        schema.TypeSet,
+								Optional:    true,
+								Elem:        &schema.Schema{Type: schema.TypeString},
+								Set:         schema.HashString,
+								Description: "Tags (topics) which are added to every `gitlab_project`, in addition to the tags configured in its `tags` attribute.",
+							},
+							"group_description_suffix": {
+								Type:        schema.TypeString,
+								Optional:    true,
+								Description: "A suffix which is appended to the description of every `gitlab_group` with a non-empty description, e.g. ` (managed by Terraform)`. Groups without a description are left without one. It should not end with whitespace, because GitLab may trim it.",
+							},
+						},
+					},
+				},
 			},
 
 			DataSourcesMap: resourceFactoriesToMap(allDataSources),
@@ -96,13 +206,36 @@ func New(version string) func() *schema.Provider {
 func configure(version string, p *schema.Provider) func(context.Context, *schema.ResourceData) (interface{}, diag.Diagnostics) {
 	return func(ctx context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
 		config := Config{
+			AuthMethod:    d.Get("auth_method").(string),
 			Token:         d.Get("token").(string),
+			Username:      d.Get("username").(string),
+			Password:      d.Get("password").(string),
+			ClientID:      d.Get("client_id").(string),
+			ClientSecret:  d.Get("client_secret").(string),
 			BaseURL:       d.Get("base_url").(string),
 			CACertFile:    d.Get("cacert_file").(string),
 			Insecure:      d.Get("insecure").(bool),
 			ClientCert:    d.Get("client_cert").(string),
 			ClientKey:     d.Get("client_key").(string),
 			EarlyAuthFail: d.Get("early_auth_check").(bool),
+
+			ProxyURL: d.Get("proxy_url").(string),
+			NoProxy:  d.Get("no_proxy").(string),
+			Headers:  expandHeaders(d.Get("headers").(map[string]interface{})),
+			ReadOnly: d.Get("read_only").(bool),
+
+			MaxRetries:        d.Get("max_retries").(int),
+			RetryWaitMin:      time.Duration(d.Get("min_backoff").(int)) * time.Second,
+			RetryWaitMax:      time.Duration(d.Get("max_backoff").(int)) * time.Second,
+			RequestsPerSecond: d.Get("requests_per_second").(float64),
+		}
+
+		if diags := validateAuthConfig(&config); diags.HasError() {
+			return nil, diags
+		}
+
+		if config.RetryWaitMin > config.RetryWaitMax {
+			return nil, diag.Errorf("`min_backoff` (%s) must not be greater than `max_backoff` (%s)", config.RetryWaitMin, config.RetryWaitMax)
 		}
 
 		client, err := config.Client()
@@ -113,8 +246,50 @@ func configure(version string, p *schema.Provider) func(context.Context, *schema
 		userAgent := p.UserAgent("terraform-provider-gitlab", version)
 		client.UserAgent = userAgent
 
-		return client, nil
+		meta := newProviderMeta(client)
+		meta.defaults = expandResourceDefaults(d)
+		if config.authenticatedUser != nil {
+			meta.cache.addUser(config.authenticatedUser)
+		}
+
+		return meta, nil
+	}
+}
+
+// validateAuthConfig checks that the arguments required by the configured authentication method are set.
+func validateAuthConfig(config *Config) diag.Diagnostics {
+	switch config.AuthMethod {
+	case authMethodToken:
+		if config.Token == "" {
+			return diag.Errorf("`token` must be set when `auth_method` is %q", config.AuthMethod)
+		}
+	case authMethodJobToken:
+		if config.Token == "" {
+			config.Token = os.Getenv("CI_JOB_TOKEN")
+		}
+		if config.Token == "" {
+			return diag.Errorf("`token` or the `CI_JOB_TOKEN` environment variable must be set when `auth_method` is %q", config.AuthMethod)
+		}
+	case authMethodPassword:
+		if config.Username == "" || config.Password == "" {
+			return diag.Errorf("`username` and `password` must be set when `auth_method` is %q", config.AuthMethod)
+		}
+	case authMethodClientCredentials:
+		if config.ClientID == "" || config.ClientSecret == "" {
+			return diag.Errorf("`client_id` and `client_secret` must be set when `auth_method` is %q", config.AuthMethod)
+		}
+	}
+
+	return nil
+}
+
+// expandHeaders converts the `headers` map of the provider configuration.
+func expandHeaders(headers map[string]interface{}) map[string]string {
+	result := make(map[string]string, len(headers))
+	for name, value := range headers {
+		result[name] = value.(string)
 	}
+	return result
 }
 
 func makeRegisterResourceFunc(factories map[string]func() *schema.Resource, resourceType string) func(name string, fn func() *schema.Resource) interface{} {
@@ -143,7 +318,7 @@ func resourceFactoriesToMap(factories map[string]func() *schema.Resource) map[st
 	resourcesMap := make(map[string]*schema.Resource)
 
 	for name, fn := range factories {
-		resourcesMap[name] = fn()
+		resourcesMap[name] = withReadOnlyDiagnostics(name, fn())
 	}
 
 	return resourcesMap
@@ -0,0 +1,114 @@
+package provider
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/xanzy/go-gitlab"
+)
+
+// providerMeta is the meta value the provider passes to every resource and data source.
+// Besides the configured REST and GraphQL clients, it gives access to information about the GitLab instance,
+// like its version and edition. This information is fetched lazily and cached,
+// so that it doesn't cost additional API calls for every resource.
+type providerMeta struct {
+	client   *gitlab.Client
+	graphql  *graphQLClient
+	cache    *metadataCache
+	defaults resourceDefaults
+}
+
+func newProviderMeta(client *gitlab.Client) *providerMeta {
+	return &providerMeta{
+		client:  client,
+		graphql: newGraphQLClient(client),
+		cache:   newMetadataCache(client),
+	}
+}
+
+// gitlabFeatureMinVersions maps features which are not available in all GitLab versions
+// supported by the provider to the minimum GitLab version supporting them.
+var gitlabFeatureMinVersions = map[string]string{
+	"project_squash_option": "14.1",
+	// The transfer group endpoint, see https://docs.gitlab.com/ee/api/groups.html#transfer-a-group
+	"group_transfer": "14.6",
+	// The `report_type` attribute of the create and update project-level rule endpoints, see
+	// https://docs.gitlab.com/ee/api/merge_request_approvals.html#create-project-level-rule
+	"approval_rule_report_type": "15.0",
+	// The `required_approval_count` attribute of group-level protected environments, see
+	// https://docs.gitlab.com/ee/api/group_protected_environments.html#protect-a-single-environment
+	"group_protected_environment_approvals": "14.9",
+	// The group-level approval rules endpoints, which are also behind the `approval_group_rules` feature flag, see
+	// https://docs.gitlab.com/ee/api/merge_request_approvals.html#create-group-level-approval-rules
+	"group_approval_rules": "16.7",
+	// The endpoint exposing the public key of a push mirror with SSH authentication, see
+	// https://docs.gitlab.com/ee/api/remote_mirrors.html#get-a-single-projects-remote-mirror-public-key
+	"project_mirror_public_key": "17.9",
+}
+
+// version returns the version of the GitLab instance, e.g. `14.8.2-ee`.
+func (m *providerMeta) version() (string, error) {
+	version, err := m.cache.Version()
+	if err != nil {
+		return "", err
+	}
+	return version.Version, nil
+}
+
+// isCE returns true if the GitLab instance is known to run the Community Edition.
+// Released versions of the Enterprise Edition end with `-ee`, but pre-releases of both editions,
+// like the ones running on gitlab.com, end with e.g. `-pre`, so their edition isn't known.
+func (m *providerMeta) isCE() (bool, error) {
+	version, err := m.version()
+	if err != nil {
+		return false, err
+	}
+	return !strings.Contains(version, "-"), nil
+}
+
+// isGitLabVersionAtLeast checks that the version of GitLab is at least the provided wantVersion.
+func (m *providerMeta) isGitLabVersionAtLeast(wantVersion string) (bool, error) {
+	version, err := m.version()
+	if err != nil {
+		return false, err
+	}
+	return compareGitLabVersionAtLeast(version, wantVersion)
+}
+
+// supportsFeature checks if the GitLab instance supports the given feature,
+// which must be listed in gitlabFeatureMinVersions.
+func (m *providerMeta) supportsFeature(feature string) (bool, error) {
+	minVersion, ok := gitlabFeatureMinVersions[feature]
+	if !ok {
+		return false, fmt.Errorf("unknown GitLab feature %q", feature)
+	}
+	return m.isGitLabVersionAtLeast(minVersion)
+}
+
+// premiumDiagnostics returns the error diagnostic for a failed request to an API which requires GitLab Premium.
+// GitLab responds with 403 or 404 if the instance, or on gitlab.com the namespace, doesn't have a Premium
+// (or higher) license, thus a hint about it is added to those errors. The given format and arguments
+// describe the failed operation, e.g. "failed to create approval rule of project %s".
+func (m *providerMeta) premiumDiagnostics(err error, format string, a ...interface{}) diag.Diagnostics {
+	summary := fmt.Sprintf("%s: %v", fmt.Sprintf(format, a...), err)
+
+	var errResponse *gitlab.ErrorResponse
+	if !errors.As(err, &errResponse) || errResponse.Response == nil ||
+		(errResponse.Response.StatusCode != http.StatusForbidden && errResponse.Response.StatusCode != http.StatusNotFound) {
+		return diag.Diagnostics{{Severity: diag.Error, Summary: summary}}
+	}
+
+	detail := "This feature requires GitLab Premium (or higher). GitLab responds with 403 or 404 if the GitLab instance, or on gitlab.com the top-level group, doesn't have such a license."
+	if isCE, versionErr := m.isCE(); versionErr == nil && isCE {
+		detail = "This feature is only available in GitLab Enterprise Edition with a Premium (or higher) license, but the configured GitLab instance runs the Community Edition."
+	}
+
+	return diag.Diagnostics{{
+		Severity: diag.Error,
+		Summary:  summary,
+		Detail:   detail,
+	}}
+}
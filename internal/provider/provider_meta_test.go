@@ -0,0 +1,97 @@
+package provider
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/xanzy/go-gitlab"
+)
+
+func testProviderMetaWithVersion(t *testing.T, version string) *providerMeta {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"version": %q}`, version)
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := gitlab.NewClient("token", gitlab.WithBaseURL(server.URL+"/api/v4/"))
+	if err != nil {
+		t.Fatalf("could not create client: %v", err)
+	}
+
+	return newProviderMeta(client)
+}
+
+func TestProviderMeta_edition(t *testing.T) {
+	cases := []struct {
+		Version string
+		IsCE    bool
+	}{
+		{Version: "14.8.2-ee", IsCE: false},
+		{Version: "14.8.2", IsCE: true},
+		// gitlab.com and nightly builds
+		{Version: "15.0.0-pre", IsCE: false},
+	}
+
+	notFound := &gitlab.ErrorResponse{
+		Response: &http.Response{
+			StatusCode: http.StatusNotFound,
+			Request:    &http.Request{Method: http.MethodPost, URL: &url.URL{Path: "/api/v4/projects/1/approval_rules"}},
+		},
+		Message: "404 Not Found",
+	}
+
+	for _, tc := range cases {
+		meta := testProviderMetaWithVersion(t, tc.Version)
+
+		isCE, err := meta.isCE()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if isCE != tc.IsCE {
+			t.Fatalf("version %q: got isCE %v expected %v", tc.Version, isCE, tc.IsCE)
+		}
+
+		diags := meta.premiumDiagnostics(notFound, "failed to create approval rule of project %s", "1")
+		if !diags.HasError() || !strings.Contains(diags[0].Detail, "Premium") {
+			t.Fatalf("version %q: expected a hint about GitLab Premium, got %v", tc.Version, diags)
+		}
+		if strings.Contains(diags[0].Detail, "Community Edition") != tc.IsCE {
+			t.Fatalf("version %q: got detail %q", tc.Version, diags[0].Detail)
+		}
+	}
+
+	if diags := testProviderMetaWithVersion(t, "14.8.2").premiumDiagnostics(fmt.Errorf("boom"), "failed"); diags[0].Detail != "" {
+		t.Fatalf("expected no hint for other errors, got %q", diags[0].Detail)
+	}
+}
+
+func TestProviderMeta_supportsFeature(t *testing.T) {
+	cases := []struct {
+		Version  string
+		Supports bool
+	}{
+		{Version: "14.0.0-ee", Supports: false},
+		{Version: "14.1.0", Supports: true},
+		{Version: "15.0.0-pre", Supports: true},
+	}
+
+	for _, tc := range cases {
+		supports, err := testProviderMetaWithVersion(t, tc.Version).supportsFeature("project_squash_option")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if supports != tc.Supports {
+			t.Fatalf("version %q: got %v expected %v", tc.Version, supports, tc.Supports)
+		}
+	}
+
+	if _, err := testProviderMetaWithVersion(t, "14.1.0").supportsFeature("unknown"); err == nil {
+		t.Fatalf("expected an error for an unknown feature")
+	}
+}
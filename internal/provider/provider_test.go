@@ -3,6 +3,7 @@ package provider
 import (
 	"os"
 	"testing"
+	"time"
 
 	// "github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
@@ -29,6 +30,9 @@ var testGitlabConfig = Config{
 	ClientCert:    "",
 	ClientKey:     "",
 	EarlyAuthFail: true,
+	MaxRetries:    5,
+	RetryWaitMin:  time.Second,
+	RetryWaitMax:  30 * time.Second,
 }
 
 var testGitlabClient *gitlab.Client
@@ -0,0 +1,82 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// readOnlyErrorMarker is part of the message of every readOnlyError.
+// The SDK converts errors to plain diagnostics, thus the marker is used to recognize them again.
+const readOnlyErrorMarker = "blocked by read-only mode"
+
+// readOnlyError is returned by the readOnlyTransport for requests which may change data in GitLab.
+type readOnlyError struct {
+	method string
+	path   string
+}
+
+func (e *readOnlyError) Error() string {
+	return fmt.Sprintf("%s %s %s", e.method, e.path, readOnlyErrorMarker)
+}
+
+// readOnlyTransport rejects all requests which may change data in GitLab,
+// so that a provider configured with `read_only` is safe to use for plans with a privileged token.
+type readOnlyTransport struct {
+	base http.RoundTripper
+}
+
+func (t *readOnlyTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	switch {
+	// GraphQL queries are sent as GET requests, see graphQLClient.Query.
+	case req.Method == http.MethodGet, req.Method == http.MethodHead:
+	default:
+		return nil, &readOnlyError{method: req.Method, path: req.URL.Path}
+	}
+	return t.base.RoundTrip(req)
+}
+
+type resourceContextFunc = func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics
+
+// withReadOnlyDiagnostics wraps the CRUD functions of the resource (or data source) with the given name,
+// so that requests blocked by the readOnlyTransport are reported with the resource that tried to send them.
+func withReadOnlyDiagnostics(name string, r *schema.Resource) *schema.Resource {
+	if r.CreateContext != nil {
+		r.CreateContext = schema.CreateContextFunc(wrapReadOnlyDiagnostics(name, "create", r.CreateContext))
+	}
+	if r.ReadContext != nil {
+		r.ReadContext = schema.ReadContextFunc(wrapReadOnlyDiagnostics(name, "read", r.ReadContext))
+	}
+	if r.UpdateContext != nil {
+		r.UpdateContext = schema.UpdateContextFunc(wrapReadOnlyDiagnostics(name, "update", r.UpdateContext))
+	}
+	if r.DeleteContext != nil {
+		r.DeleteContext = schema.DeleteContextFunc(wrapReadOnlyDiagnostics(name, "delete", r.DeleteContext))
+	}
+	return r
+}
+
+func wrapReadOnlyDiagnostics(name string, operation string, fn resourceContextFunc) resourceContextFunc {
+	return func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+		diags := fn(ctx, d, meta)
+		for i := range diags {
+			if !strings.Contains(diags[i].Summary, readOnlyErrorMarker) {
+				continue
+			}
+
+			resource := name
+			if d.Id() != "" {
+				resource = fmt.Sprintf("%s %q", name, d.Id())
+			}
+			diags[i].Detail = fmt.Sprintf(
+				"The provider is configured with `read_only = true`, which blocks all requests that may change data in GitLab. "+
+					"The %s of %s tried to send: %s", operation, resource, diags[i].Summary)
+			diags[i].Summary = fmt.Sprintf("Cannot %s %s: the provider is read-only", operation, resource)
+		}
+		return diags
+	}
+}
@@ -0,0 +1,69 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/xanzy/go-gitlab"
+)
+
+func TestConfigClient_readOnly(t *testing.T) {
+	var gotMethods []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotMethods = append(gotMethods, r.Method)
+		fmt.Fprint(w, `{"id": 1, "name": "foo"}`)
+	}))
+	t.Cleanup(server.Close)
+
+	config := Config{AuthMethod: authMethodToken, Token: "pat", BaseURL: server.URL + "/api/v4/", ReadOnly: true}
+	client, err := config.Client()
+	if err != nil {
+		t.Fatalf("could not create client: %v", err)
+	}
+
+	if _, _, err := client.Projects.GetProject(1, nil); err != nil {
+		t.Fatalf("GET request failed: %v", err)
+	}
+
+	_, _, err = client.Projects.CreateProject(&gitlab.CreateProjectOptions{Name: gitlab.String("foo")})
+	var readOnlyErr *readOnlyError
+	if !errors.As(err, &readOnlyErr) {
+		t.Fatalf("expected a read-only error for POST request, got: %v", err)
+	}
+
+	for _, method := range gotMethods {
+		if method != http.MethodGet {
+			t.Fatalf("expected only GET requests to reach GitLab, got: %v", gotMethods)
+		}
+	}
+}
+
+func TestWithReadOnlyDiagnostics(t *testing.T) {
+	r := withReadOnlyDiagnostics("gitlab_project", &schema.Resource{
+		Schema: map[string]*schema.Schema{},
+		DeleteContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+			return diag.FromErr(&readOnlyError{method: http.MethodDelete, path: "/api/v4/projects/42"})
+		},
+	})
+
+	d := r.TestResourceData()
+	d.SetId("42")
+
+	diags := r.DeleteContext(context.Background(), d, nil)
+	if len(diags) != 1 {
+		t.Fatalf("expected exactly one diagnostic, got: %v", diags)
+	}
+	if expected := `Cannot delete gitlab_project "42": the provider is read-only`; diags[0].Summary != expected {
+		t.Fatalf("got summary %q expected %q", diags[0].Summary, expected)
+	}
+	if !strings.Contains(diags[0].Detail, "DELETE /api/v4/projects/42") {
+		t.Fatalf("expected detail to contain the blocked request, got %q", diags[0].Detail)
+	}
+}
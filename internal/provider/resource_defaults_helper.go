@@ -0,0 +1,115 @@
+package provider
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// resourceDefaults are configured in the `resource_defaults` provider block
+// and applied to every resource of the respective type.
+type resourceDefaults struct {
+	// projectTags are merged into the tags of every gitlab_project.
+	projectTags []string
+	// groupDescriptionSuffix is appended to the description of every gitlab_group.
+	groupDescriptionSuffix string
+}
+
+func expandResourceDefaults(d *schema.ResourceData) resourceDefaults {
+	var defaults resourceDefaults
+
+	if v, ok := d.GetOk("resource_defaults.0.project_tags"); ok {
+		defaults.projectTags = *stringSetToStringSlice(v.(*schema.Set))
+	}
+
+	if v, ok := d.GetOk("resource_defaults.0.group_description_suffix"); ok {
+		defaults.groupDescriptionSuffix = v.(string)
+	}
+
+	return defaults
+}
+
+// mergeProjectTags returns the sorted union of the configured tags and the default project tags.
+func (defaults resourceDefaults) mergeProjectTags(tags []string) []string {
+	seen := make(map[string]bool)
+	merged := []string{}
+	for _, tag := range append(append([]string{}, tags...), defaults.projectTags...) {
+		if !seen[tag] {
+			seen[tag] = true
+			merged = append(merged, tag)
+		}
+	}
+	sort.Strings(merged)
+	return merged
+}
+
+// removeDefaultProjectTags removes the default project tags from the tags of a project,
+// unless they are also explicitly configured.
+func (defaults resourceDefaults) removeDefaultProjectTags(tags []string, configuredTags []string) []string {
+	result := []string{}
+	for _, tag := range tags {
+		if contains(defaults.projectTags, tag) && !contains(configuredTags, tag) {
+			continue
+		}
+		result = append(result, tag)
+	}
+	return result
+}
+
+// appendGroupDescriptionSuffix appends the default group description suffix to the given description.
+// Empty descriptions are left empty, so that groups without a description don't get the bare suffix.
+func (defaults resourceDefaults) appendGroupDescriptionSuffix(description string) string {
+	if description == "" {
+		return description
+	}
+	return description + defaults.groupDescriptionSuffix
+}
+
+// trimGroupDescriptionSuffix removes the default group description suffix from the description of a group.
+func (defaults resourceDefaults) trimGroupDescriptionSuffix(description string) string {
+	if defaults.groupDescriptionSuffix == "" {
+		return description
+	}
+	return strings.TrimSuffix(description, defaults.groupDescriptionSuffix)
+}
+
+// customizeDiffProjectTagsAll plans `tags_all` as the union of `tags` and the default project tags,
+// so that changes to the defaults show up in the plan of every project.
+func customizeDiffProjectTagsAll(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("tags") {
+		return d.SetNewComputed("tags_all")
+	}
+
+	tags := *stringSetToStringSlice(d.Get("tags").(*schema.Set))
+	tagsAll := meta.(*providerMeta).defaults.mergeProjectTags(tags)
+
+	if d.Get("tags_all").(*schema.Set).Equal(schema.NewSet(schema.HashString, stringSliceToInterfaceSlice(tagsAll))) {
+		return nil
+	}
+	return d.SetNew("tags_all", tagsAll)
+}
+
+// customizeDiffGroupDescriptionAll plans `description_all` as the `description` with the default group
+// description suffix, so that changes to the suffix show up in the plan of every group.
+func customizeDiffGroupDescriptionAll(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("description") {
+		return d.SetNewComputed("description_all")
+	}
+
+	descriptionAll := meta.(*providerMeta).defaults.appendGroupDescriptionSuffix(d.Get("description").(string))
+
+	if d.Get("description_all").(string) == descriptionAll {
+		return nil
+	}
+	return d.SetNew("description_all", descriptionAll)
+}
+
+func stringSliceToInterfaceSlice(values []string) []interface{} {
+	result := make([]interface{}, 0, len(values))
+	for _, v := range values {
+		result = append(result, v)
+	}
+	return result
+}
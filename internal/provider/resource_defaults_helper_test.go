@@ -0,0 +1,49 @@
+package provider
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestResourceDefaults_projectTags(t *testing.T) {
+	defaults := resourceDefaults{projectTags: []string{"managed", "shared"}}
+
+	merged := defaults.mergeProjectTags([]string{"foo", "shared"})
+	if expected := []string{"foo", "managed", "shared"}; !reflect.DeepEqual(merged, expected) {
+		t.Fatalf("got merged tags %v expected %v", merged, expected)
+	}
+
+	removed := defaults.removeDefaultProjectTags(merged, []string{"foo", "shared"})
+	if expected := []string{"foo", "shared"}; !reflect.DeepEqual(removed, expected) {
+		t.Fatalf("got tags %v expected %v", removed, expected)
+	}
+
+	if merged := (resourceDefaults{}).mergeProjectTags(nil); len(merged) != 0 {
+		t.Fatalf("got merged tags %v expected none", merged)
+	}
+}
+
+func TestResourceDefaults_groupDescriptionSuffix(t *testing.T) {
+	cases := []struct {
+		Suffix      string
+		Description string
+		Expected    string
+	}{
+		{Suffix: "", Description: "foo", Expected: "foo"},
+		{Suffix: " (managed by Terraform)", Description: "foo", Expected: "foo (managed by Terraform)"},
+		{Suffix: " (managed by Terraform)", Description: "", Expected: ""},
+	}
+
+	for _, tc := range cases {
+		defaults := resourceDefaults{groupDescriptionSuffix: tc.Suffix}
+
+		got := defaults.appendGroupDescriptionSuffix(tc.Description)
+		if got != tc.Expected {
+			t.Fatalf("got description %q expected %q", got, tc.Expected)
+		}
+
+		if trimmed := defaults.trimGroupDescriptionSuffix(got); trimmed != tc.Description {
+			t.Fatalf("got trimmed description %q expected %q", trimmed, tc.Description)
+		}
+	}
+}
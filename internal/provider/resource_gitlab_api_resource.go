@@ -0,0 +1,260 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/structure"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// gitlabAPIResourceIDPlaceholder is replaced with the id of the object in the read, update and delete paths.
+const gitlabAPIResourceIDPlaceholder = "{id}"
+
+var _ = registerResource("gitlab_api_resource", func() *schema.Resource {
+	return &schema.Resource{
+		Description: `The ` + "`gitlab_api_resource`" + ` resource allows to manage the lifecycle of an arbitrary object of the GitLab REST API,
+using the authentication and connection settings of the provider. It is meant as an escape hatch to manage GitLab features
+for which there is no dedicated resource yet.
+
+The object is created by sending the ` + "`body`" + ` with a ` + "`POST`" + ` request to the ` + "`create_path`" + `
+and its id is taken from the ` + "`id_attribute`" + ` of the response. The read, update and delete paths may contain
+the ` + "`{id}`" + ` placeholder, which is replaced with that id.
+
+Only the ` + "`tracked_attributes`" + ` of the object are checked for drift, because most APIs return more (and differently named)
+attributes than they accept.
+
+-> This resource can't be imported, because the paths of the object are not known during the import.
+
+**Upstream API**: [GitLab REST API docs](https://docs.gitlab.com/ee/api/api_resources.html)`,
+
+		CreateContext: resourceGitlabAPIResourceCreate,
+		ReadContext:   resourceGitlabAPIResourceRead,
+		UpdateContext: resourceGitlabAPIResourceUpdate,
+		DeleteContext: resourceGitlabAPIResourceDelete,
+
+		Schema: map[string]*schema.Schema{
+			"create_path": {
+				Description:  "The path of the API endpoint to create the object with, relative to `/api/v4/`, e.g. `projects/42/hooks`.",
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"read_path": {
+				Description:  "The path of the API endpoint to read the object from, relative to `/api/v4/`, e.g. `projects/42/hooks/{id}`.",
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"update_path": {
+				Description: "The path of the API endpoint to update the object with, relative to `/api/v4/`. Defaults to the `read_path`.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"update_method": {
+				Description:  "The HTTP method used to update the object. Valid values are: `PUT`, `POST`.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      http.MethodPut,
+				ValidateFunc: validation.StringInSlice([]string{http.MethodPut, http.MethodPost}, false),
+			},
+			"delete_path": {
+				Description: "The path of the API endpoint to delete the object with, relative to `/api/v4/`. Defaults to the `read_path`.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"id_attribute": {
+				Description:  "The attribute of the create response which contains the id of the object.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				Default:      "id",
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"body": {
+				Description:      "The JSON encoded body sent to create and update the object. Use the `jsonencode()` function to build it.",
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateFunc:     validation.StringIsJSON,
+				DiffSuppressFunc: structure.SuppressJsonDiff,
+			},
+			"tracked_attributes": {
+				Description: "The top-level attributes of the `body` which are compared with the object read from the API to detect drift. Attributes which are missing in the `body` or the object are ignored.",
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"response": {
+				Description: "The JSON encoded object, as read from the API. Use the `jsondecode()` function to access it.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+		},
+	}
+})
+
+func resourceGitlabAPIResourceCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+	path := d.Get("create_path").(string)
+
+	log.Printf("[DEBUG] create gitlab API resource at %q", path)
+
+	result, _, err := gitlabAPIRequest(ctx, client, http.MethodPost, path, nil, json.RawMessage(d.Get("body").(string)))
+	if err != nil {
+		return diag.Errorf("failed to create gitlab API resource at %q: %v", path, err)
+	}
+
+	id, err := gitlabAPIResourceID(result, d.Get("id_attribute").(string))
+	if err != nil {
+		return diag.Errorf("failed to create gitlab API resource at %q: %v", path, err)
+	}
+	d.SetId(id)
+
+	return resourceGitlabAPIResourceRead(ctx, d, meta)
+}
+
+func resourceGitlabAPIResourceRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+	path := gitlabAPIResourcePath(d.Get("read_path").(string), d.Id())
+
+	log.Printf("[DEBUG] read gitlab API resource %q", path)
+
+	result, _, err := gitlabAPIRequest(ctx, client, http.MethodGet, path, nil, nil)
+	if err != nil {
+		if is404(err) {
+			log.Printf("[DEBUG] gitlab API resource %q not found, removing from state", path)
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("failed to read gitlab API resource %q: %v", path, err)
+	}
+
+	response, err := structure.NormalizeJsonString(string(result))
+	if err != nil {
+		return diag.Errorf("failed to decode gitlab API resource %q: %v", path, err)
+	}
+	d.Set("response", response)
+
+	body, drifted, err := gitlabAPIResourceDetectDrift(d.Get("body").(string), result, *stringSetToStringSlice(d.Get("tracked_attributes").(*schema.Set)))
+	if err != nil {
+		return diag.Errorf("failed to detect drift of gitlab API resource %q: %v", path, err)
+	}
+	if drifted {
+		log.Printf("[DEBUG] gitlab API resource %q has drifted", path)
+		d.Set("body", body)
+	}
+
+	return nil
+}
+
+func resourceGitlabAPIResourceUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+	path := d.Get("update_path").(string)
+	if path == "" {
+		path = d.Get("read_path").(string)
+	}
+	path = gitlabAPIResourcePath(path, d.Id())
+	method := d.Get("update_method").(string)
+
+	if d.HasChange("body") {
+		log.Printf("[DEBUG] update gitlab API resource %q with %s", path, method)
+
+		if _, _, err := gitlabAPIRequest(ctx, client, method, path, nil, json.RawMessage(d.Get("body").(string))); err != nil {
+			return diag.Errorf("failed to update gitlab API resource %q: %v", path, err)
+		}
+	}
+
+	return resourceGitlabAPIResourceRead(ctx, d, meta)
+}
+
+func resourceGitlabAPIResourceDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+	path := d.Get("delete_path").(string)
+	if path == "" {
+		path = d.Get("read_path").(string)
+	}
+	path = gitlabAPIResourcePath(path, d.Id())
+
+	log.Printf("[DEBUG] delete gitlab API resource %q", path)
+
+	if _, _, err := gitlabAPIRequest(ctx, client, http.MethodDelete, path, nil, nil); err != nil && !is404(err) {
+		return diag.Errorf("failed to delete gitlab API resource %q: %v", path, err)
+	}
+
+	return nil
+}
+
+// gitlabAPIResourcePath replaces the id placeholder in the given path with the URL encoded id.
+func gitlabAPIResourcePath(path string, id string) string {
+	return strings.ReplaceAll(path, gitlabAPIResourceIDPlaceholder, url.PathEscape(id))
+}
+
+// gitlabAPIResourceID returns the value of the given attribute of a JSON object as id.
+func gitlabAPIResourceID(result json.RawMessage, attribute string) (string, error) {
+	var object map[string]interface{}
+	if err := json.Unmarshal(result, &object); err != nil {
+		return "", fmt.Errorf("response is not a JSON object: %v", err)
+	}
+
+	switch id := object[attribute].(type) {
+	case string:
+		if id != "" {
+			return id, nil
+		}
+	case float64:
+		return strconv.FormatFloat(id, 'f', -1, 64), nil
+	}
+
+	return "", fmt.Errorf("response has no id in attribute %q", attribute)
+}
+
+// gitlabAPIResourceDetectDrift compares the tracked attributes of the body with the object read from the API.
+// If any of them differ, the body is returned with the values from the API, which makes the difference show up in the plan.
+func gitlabAPIResourceDetectDrift(body string, result json.RawMessage, trackedAttributes []string) (string, bool, error) {
+	if len(trackedAttributes) == 0 {
+		return body, false, nil
+	}
+
+	var bodyObject map[string]interface{}
+	if err := json.Unmarshal([]byte(body), &bodyObject); err != nil {
+		return "", false, fmt.Errorf("body is not a JSON object: %v", err)
+	}
+
+	var remoteObject map[string]interface{}
+	if err := json.Unmarshal(result, &remoteObject); err != nil {
+		return "", false, fmt.Errorf("response is not a JSON object: %v", err)
+	}
+
+	drifted := false
+	for _, attribute := range trackedAttributes {
+		bodyValue, inBody := bodyObject[attribute]
+		remoteValue, inRemote := remoteObject[attribute]
+		if !inBody || !inRemote {
+			continue
+		}
+		if !reflect.DeepEqual(bodyValue, remoteValue) {
+			bodyObject[attribute] = remoteValue
+			drifted = true
+		}
+	}
+
+	if !drifted {
+		return body, false, nil
+	}
+
+	newBody, err := json.Marshal(bodyObject)
+	if err != nil {
+		return "", false, err
+	}
+	return string(newBody), true, nil
+}
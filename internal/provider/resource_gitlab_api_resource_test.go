@@ -0,0 +1,208 @@
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/xanzy/go-gitlab"
+)
+
+func TestAccGitlabAPIResource_basic(t *testing.T) {
+	testAccCheck(t)
+	project := testAccCreateProject(t)
+	var hook gitlab.ProjectHook
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: providerFactories,
+		CheckDestroy:      testAccCheckGitlabAPIResourceHookDestroy(project.ID),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccGitlabAPIResourceHookConfig(project.ID, "https://example.com/hook", true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckGitlabAPIResourceHookExists("gitlab_api_resource.hook", project.ID, &hook),
+					func(*terraform.State) error {
+						if hook.URL != "https://example.com/hook" || !hook.PushEvents {
+							return fmt.Errorf("unexpected hook: %+v", hook)
+						}
+						return nil
+					},
+				),
+			},
+			{
+				Config: testAccGitlabAPIResourceHookConfig(project.ID, "https://example.com/other-hook", false),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckGitlabAPIResourceHookExists("gitlab_api_resource.hook", project.ID, &hook),
+					func(*terraform.State) error {
+						if hook.URL != "https://example.com/other-hook" || hook.PushEvents {
+							return fmt.Errorf("unexpected hook: %+v", hook)
+						}
+						return nil
+					},
+				),
+			},
+			// Drift of a tracked attribute is detected and reverted.
+			{
+				PreConfig: func() {
+					_, _, err := testGitlabClient.Projects.EditProjectHook(project.ID, hook.ID, &gitlab.EditProjectHookOptions{
+						URL: gitlab.String("https://example.com/drifted"),
+					})
+					if err != nil {
+						t.Fatalf("failed to edit hook: %v", err)
+					}
+				},
+				Config: testAccGitlabAPIResourceHookConfig(project.ID, "https://example.com/other-hook", false),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckGitlabAPIResourceHookExists("gitlab_api_resource.hook", project.ID, &hook),
+					func(*terraform.State) error {
+						if hook.URL != "https://example.com/other-hook" {
+							return fmt.Errorf("expected drift to be reverted, got url %q", hook.URL)
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckGitlabAPIResourceHookExists(n string, projectID int, hook *gitlab.ProjectHook) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("not found: %s", n)
+		}
+
+		hookID, err := strconv.Atoi(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		gotHook, _, err := testGitlabClient.Projects.GetProjectHook(projectID, hookID)
+		if err != nil {
+			return err
+		}
+		*hook = *gotHook
+
+		var response gitlab.ProjectHook
+		if err := json.Unmarshal([]byte(rs.Primary.Attributes["response"]), &response); err != nil {
+			return err
+		}
+		if response.ID != hookID {
+			return fmt.Errorf("expected response of hook %d, got %d", hookID, response.ID)
+		}
+		return nil
+	}
+}
+
+func testAccCheckGitlabAPIResourceHookDestroy(projectID int) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		for _, rs := range s.RootModule().Resources {
+			if rs.Type != "gitlab_api_resource" {
+				continue
+			}
+
+			hookID, err := strconv.Atoi(rs.Primary.ID)
+			if err != nil {
+				return err
+			}
+
+			_, _, err = testGitlabClient.Projects.GetProjectHook(projectID, hookID)
+			if err == nil {
+				return fmt.Errorf("hook %d still exists", hookID)
+			}
+			if !is404(err) {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+func testAccGitlabAPIResourceHookConfig(projectID int, url string, pushEvents bool) string {
+	return fmt.Sprintf(`
+resource "gitlab_api_resource" "hook" {
+  create_path = "projects/%[1]d/hooks"
+  read_path   = "projects/%[1]d/hooks/{id}"
+
+  body = jsonencode({
+    url         = "%[2]s"
+    push_events = %[3]t
+  })
+
+  tracked_attributes = ["url", "push_events"]
+}
+`, projectID, url, pushEvents)
+}
+
+func TestGitlabAPIResourcePath(t *testing.T) {
+	if got := gitlabAPIResourcePath("projects/42/variables/{id}", "MY VAR"); got != "projects/42/variables/MY%20VAR" {
+		t.Fatalf("got path %q", got)
+	}
+}
+
+func TestGitlabAPIResourceID(t *testing.T) {
+	cases := []struct {
+		Result    string
+		Attribute string
+		Expected  string
+		Error     bool
+	}{
+		{Result: `{"id": 42}`, Attribute: "id", Expected: "42"},
+		{Result: `{"key": "MY_VAR"}`, Attribute: "key", Expected: "MY_VAR"},
+		{Result: `{"id": 42}`, Attribute: "key", Error: true},
+		{Result: `[{"id": 42}]`, Attribute: "id", Error: true},
+	}
+
+	for _, tc := range cases {
+		got, err := gitlabAPIResourceID(json.RawMessage(tc.Result), tc.Attribute)
+		if tc.Error {
+			if err == nil {
+				t.Fatalf("%s: expected an error, got id %q", tc.Result, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", tc.Result, err)
+		}
+		if got != tc.Expected {
+			t.Fatalf("%s: got id %q expected %q", tc.Result, got, tc.Expected)
+		}
+	}
+}
+
+func TestGitlabAPIResourceDetectDrift(t *testing.T) {
+	body := `{"url": "https://example.com", "push_events": true, "token": "secret"}`
+
+	cases := []struct {
+		Result       string
+		Tracked      []string
+		ExpectedBody string
+	}{
+		// The untracked token is never returned by the API.
+		{Result: `{"id": 1, "url": "https://example.com", "push_events": true}`, Tracked: []string{"url", "push_events", "token"}},
+		{Result: `{"id": 1, "url": "https://example.com/drifted", "push_events": true}`, Tracked: []string{"push_events"}},
+		{
+			Result:       `{"id": 1, "url": "https://example.com/drifted", "push_events": true}`,
+			Tracked:      []string{"url", "push_events"},
+			ExpectedBody: `{"push_events":true,"token":"secret","url":"https://example.com/drifted"}`,
+		},
+	}
+
+	for _, tc := range cases {
+		got, drifted, err := gitlabAPIResourceDetectDrift(body, json.RawMessage(tc.Result), tc.Tracked)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", tc.Result, err)
+		}
+		if drifted != (tc.ExpectedBody != "") {
+			t.Fatalf("%s: got drifted %t", tc.Result, drifted)
+		}
+		if drifted && got != tc.ExpectedBody {
+			t.Fatalf("%s: got body %s expected %s", tc.Result, got, tc.ExpectedBody)
+		}
+	}
+}
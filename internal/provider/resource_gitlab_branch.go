@@ -150,7 +150,7 @@ var commitSchema = &schema.Resource{
 }
 
 func resourceGitlabBranchCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 	name := d.Get("name").(string)
 	project := d.Get("project").(string)
 	ref := d.Get("ref").(string)
@@ -170,7 +170,7 @@ func resourceGitlabBranchCreate(ctx context.Context, d *schema.ResourceData, met
 }
 
 func resourceGitlabBranchRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 	project, name, err := parseTwoPartID(d.Id())
 	if err != nil {
 		return diag.FromErr(err)
@@ -205,7 +205,7 @@ func resourceGitlabBranchRead(ctx context.Context, d *schema.ResourceData, meta
 }
 
 func resourceGitlabBranchDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 	project, name, err := parseTwoPartID(d.Id())
 	if err != nil {
 		return diag.FromErr(err)
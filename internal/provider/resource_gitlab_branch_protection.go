@@ -117,7 +117,7 @@ var _ = registerResource("gitlab_branch_protection", func() *schema.Resource {
 })
 
 func resourceGitlabBranchProtectionCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 	project := d.Get("project").(string)
 	branch := d.Get("branch").(string)
 
@@ -169,7 +169,7 @@ func resourceGitlabBranchProtectionCreate(ctx context.Context, d *schema.Resourc
 }
 
 func resourceGitlabBranchProtectionRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 	project, branch, err := projectAndBranchFromID(d.Id())
 	if err != nil {
 		return diag.FromErr(err)
@@ -236,7 +236,7 @@ func resourceGitlabBranchProtectionUpdate(ctx context.Context, d *schema.Resourc
 	// NOTE: At the time of writing, the only value that does not force re-creation is code_owner_approval_required,
 	// so therefore that is the only update that needs to be handled.
 
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 	project := d.Get("project").(string)
 	branch := d.Get("branch").(string)
 	codeOwnerApprovalRequired := d.Get("code_owner_approval_required").(bool)
@@ -261,7 +261,7 @@ func resourceGitlabBranchProtectionUpdate(ctx context.Context, d *schema.Resourc
 }
 
 func resourceGitlabBranchProtectionDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 	project := d.Get("project").(string)
 	branch := d.Get("branch").(string)
 
@@ -5,10 +5,12 @@ import (
 	"fmt"
 	"log"
 	"net/http"
+	"net/url"
 	"strings"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
@@ -28,8 +30,20 @@ var _ = registerResource("gitlab_group", func() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
-
-		Schema: map[string]*schema.Schema{
+		Timeouts: &schema.ResourceTimeout{
+			Update: schema.DefaultTimeout(10 * time.Minute),
+			Delete: schema.DefaultTimeout(10 * time.Minute),
+		},
+		CustomizeDiff: customdiff.All(
+			customdiff.ComputedIf("full_path", groupParentChanged),
+			customdiff.ComputedIf("full_name", groupParentChanged),
+			customdiff.ComputedIf("web_url", groupParentChanged),
+			customizeDiffGroupTransfer,
+			customizeDiffGroupDescriptionAll,
+			customizeDiffAvatarHash,
+		),
+
+		Schema: constructSchema(avatarSchema("group"), map[string]*schema.Schema{
 			"name": {
 				Description: "The name of this group.",
 				Type:        schema.TypeString,
@@ -60,6 +74,11 @@ var _ = registerResource("gitlab_group", func() *schema.Resource {
 				Type:        schema.TypeString,
 				Optional:    true,
 			},
+			"description_all": {
+				Description: "The description of the group, including the `group_description_suffix` configured in the `resource_defaults` provider block.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
 			"lfs_enabled": {
 				Description: "Boolean, defaults to true.  Whether to enable LFS",
 				Type:        schema.TypeBool,
@@ -136,11 +155,15 @@ var _ = registerResource("gitlab_group", func() *schema.Resource {
 				Optional:    true,
 				Default:     48,
 			},
+			"deletion_protection": {
+				Description: "Set to `true` to make destroying the group fail. It must be set to `false` (and applied) before the group can be deleted, e.g. to prevent a renamed resource address from deleting a production group.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+			},
 			"parent_id": {
-				Description: "Integer, id of the parent group (creates a nested group).",
+				Description: "Integer, id of the parent group (creates a nested group). Changing it transfers the group to the new parent group in-place, `0` makes it a top-level group. Transferring a group requires GitLab 14.6 or newer.",
 				Type:        schema.TypeInt,
 				Optional:    true,
-				ForceNew:    true,
 				Default:     0,
 			},
 			"runners_token": {
@@ -155,12 +178,12 @@ var _ = registerResource("gitlab_group", func() *schema.Resource {
 				Optional:    true,
 				Default:     false,
 			},
-		},
+		}),
 	}
 })
 
 func resourceGitlabGroupCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 	options := &gitlab.CreateGroupOptions{
 		Name:                 gitlab.String(d.Get("name").(string)),
 		LFSEnabled:           gitlab.Bool(d.Get("lfs_enabled").(bool)),
@@ -171,7 +194,8 @@ func resourceGitlabGroupCreate(ctx context.Context, d *schema.ResourceData, meta
 		options.Path = gitlab.String(v.(string))
 	}
 
-	if v, ok := d.GetOk("description"); ok {
+	// description_all contains the description with the default suffix of the provider, see customizeDiffGroupDescriptionAll.
+	if v, ok := d.GetOk("description_all"); ok {
 		options.Description = gitlab.String(v.(string))
 	}
 
@@ -242,11 +266,17 @@ func resourceGitlabGroupCreate(ctx context.Context, d *schema.ResourceData, meta
 		}
 	}
 
+	if v, ok := d.GetOk("avatar"); ok {
+		if err := updateAvatar(ctx, client, fmt.Sprintf("groups/%s", d.Id()), v.(string)); err != nil {
+			return diag.Errorf("could not upload avatar of group %q: %s", d.Id(), err)
+		}
+	}
+
 	return resourceGitlabGroupRead(ctx, d, meta)
 }
 
 func resourceGitlabGroupRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 	log.Printf("[DEBUG] read gitlab group %s", d.Id())
 
 	group, resp, err := client.Groups.GetGroup(d.Id(), nil, gitlab.WithContext(ctx))
@@ -270,7 +300,9 @@ func resourceGitlabGroupRead(ctx context.Context, d *schema.ResourceData, meta i
 	d.Set("full_path", group.FullPath)
 	d.Set("full_name", group.FullName)
 	d.Set("web_url", group.WebURL)
-	d.Set("description", group.Description)
+	d.Set("avatar_url", group.AvatarURL)
+	d.Set("description", meta.(*providerMeta).defaults.trimGroupDescriptionSuffix(group.Description))
+	d.Set("description_all", group.Description)
 	d.Set("lfs_enabled", group.LFSEnabled)
 	d.Set("request_access_enabled", group.RequestAccessEnabled)
 	d.Set("visibility_level", group.Visibility)
@@ -291,7 +323,7 @@ func resourceGitlabGroupRead(ctx context.Context, d *schema.ResourceData, meta i
 }
 
 func resourceGitlabGroupUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 
 	options := &gitlab.UpdateGroupOptions{}
 
@@ -303,8 +335,8 @@ func resourceGitlabGroupUpdate(ctx context.Context, d *schema.ResourceData, meta
 		options.Path = gitlab.String(d.Get("path").(string))
 	}
 
-	if d.HasChange("description") {
-		options.Description = gitlab.String(d.Get("description").(string))
+	if d.HasChange("description_all") {
+		options.Description = gitlab.String(d.Get("description_all").(string))
 	}
 
 	if d.HasChange("lfs_enabled") {
@@ -368,11 +400,97 @@ func resourceGitlabGroupUpdate(ctx context.Context, d *schema.ResourceData, meta
 		return diag.FromErr(err)
 	}
 
+	// Moving the group to another parent group is an in-place transfer, the group keeps its id.
+	if d.HasChange("parent_id") {
+		if diags := resourceGitlabGroupTransfer(ctx, d, client); diags.HasError() {
+			return diags
+		}
+	}
+
+	if d.HasChange("avatar_hash") {
+		if err := updateAvatar(ctx, client, fmt.Sprintf("groups/%s", d.Id()), d.Get("avatar").(string)); err != nil {
+			return diag.Errorf("could not update avatar of group %q: %s", d.Id(), err)
+		}
+	}
+
 	return resourceGitlabGroupRead(ctx, d, meta)
 }
 
+// resourceGitlabGroupTransfer transfers the group to the configured parent group and waits for the transfer to complete.
+func resourceGitlabGroupTransfer(ctx context.Context, d *schema.ResourceData, client *gitlab.Client) diag.Diagnostics {
+	parentID := d.Get("parent_id").(int)
+	oldPath, _ := d.GetChange("full_path")
+
+	target := fmt.Sprintf("parent group %d", parentID)
+	body := map[string]interface{}{}
+	if parentID != 0 {
+		body["group_id"] = parentID
+	} else {
+		// Without a target group, the group becomes a top-level group.
+		target = "the top level"
+	}
+
+	log.Printf("[DEBUG] transferring gitlab group %s to %s", d.Id(), target)
+
+	if _, _, err := gitlabAPIRequest(ctx, client, http.MethodPost, fmt.Sprintf("groups/%s/transfer", url.PathEscape(d.Id())), nil, body); err != nil {
+		return transferDiagnostics("group", oldPath.(string), target, err)
+	}
+
+	// Wait for the group to show up in the new parent group.
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{"Transferring"},
+		Target:  []string{"Transferred"},
+		Refresh: func() (interface{}, string, error) {
+			out, _, err := client.Groups.GetGroup(d.Id(), nil, gitlab.WithContext(ctx))
+			if err != nil {
+				log.Printf("[ERROR] Received error: %#v", err)
+				return out, "Error", err
+			}
+			if out.ParentID == parentID {
+				return out, "Transferred", nil
+			}
+			return out, "Transferring", nil
+		},
+
+		Timeout:    d.Timeout(schema.TimeoutUpdate),
+		MinTimeout: 3 * time.Second,
+	}
+
+	if _, err := stateConf.WaitForStateContext(ctx); err != nil {
+		return diag.Errorf("error waiting for group (%s) to be transferred to %s: %s", d.Id(), target, err)
+	}
+
+	return nil
+}
+
+func groupParentChanged(ctx context.Context, d *schema.ResourceDiff, meta interface{}) bool {
+	return d.HasChange("parent_id")
+}
+
+// customizeDiffGroupTransfer fails the plan if the parent group of an existing group changes,
+// but the GitLab instance doesn't support transferring groups yet.
+func customizeDiffGroupTransfer(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() == "" || !d.HasChange("parent_id") {
+		return nil
+	}
+
+	supported, err := meta.(*providerMeta).supportsFeature("group_transfer")
+	if err != nil {
+		return err
+	}
+	if !supported {
+		return fmt.Errorf("changing `parent_id` transfers the group, which requires GitLab %s or newer", gitlabFeatureMinVersions["group_transfer"])
+	}
+	return nil
+}
+
 func resourceGitlabGroupDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
+
+	if d.Get("deletion_protection").(bool) {
+		return deletionProtectionDiagnostics("group", d.Get("full_path").(string))
+	}
+
 	log.Printf("[DEBUG] Delete gitlab group %s", d.Id())
 
 	_, err := client.Groups.DeleteGroup(d.Id(), gitlab.WithContext(ctx))
@@ -401,7 +519,7 @@ func resourceGitlabGroupDelete(ctx context.Context, d *schema.ResourceData, meta
 			return out, "Deleting", nil
 		},
 
-		Timeout:    10 * time.Minute,
+		Timeout:    d.Timeout(schema.TimeoutDelete),
 		MinTimeout: 3 * time.Second,
 		Delay:      5 * time.Second,
 	}
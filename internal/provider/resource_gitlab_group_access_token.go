@@ -121,7 +121,7 @@ var _ = registerResource("gitlab_group_access_token", func() *schema.Resource {
 })
 
 func resourceGitlabGroupAccessTokenCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 
 	group := d.Get("group").(string)
 	options := &gitlab.CreateGroupAccessTokenOptions{
@@ -166,7 +166,7 @@ func resourceGitlabGroupAccessTokenRead(ctx context.Context, d *schema.ResourceD
 		return diag.Errorf("Error parsing ID: %s", d.Id())
 	}
 
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 
 	groupAccessTokenId, err := strconv.Atoi(tokenId)
 	if err != nil {
@@ -228,7 +228,7 @@ func resourceGitlabGroupAccessTokenDelete(ctx context.Context, d *schema.Resourc
 		return diag.Errorf("Error parsing ID: %s", d.Id())
 	}
 
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 
 	groupAccessTokenId, err := strconv.Atoi(tokenId)
 	if err != nil {
@@ -0,0 +1,305 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	gitlab "github.com/xanzy/go-gitlab"
+)
+
+// groupApprovalRule is a group-level approval rule, which isn't supported by the GitLab client yet.
+type groupApprovalRule struct {
+	ID                int                       `json:"id"`
+	Name              string                    `json:"name"`
+	RuleType          string                    `json:"rule_type"`
+	ReportType        string                    `json:"report_type"`
+	ApprovalsRequired int                       `json:"approvals_required"`
+	Users             []*gitlab.BasicUser       `json:"users"`
+	Groups            []*gitlab.Group           `json:"groups"`
+	ProtectedBranches []*gitlab.ProtectedBranch `json:"protected_branches"`
+}
+
+// groupApprovalRuleOptions are the options to create or update a group-level approval rule.
+type groupApprovalRuleOptions struct {
+	Name               *string `json:"name,omitempty"`
+	ApprovalsRequired  *int    `json:"approvals_required,omitempty"`
+	RuleType           *string `json:"rule_type,omitempty"`
+	ReportType         *string `json:"report_type,omitempty"`
+	UserIDs            *[]int  `json:"user_ids,omitempty"`
+	GroupIDs           *[]int  `json:"group_ids,omitempty"`
+	ProtectedBranchIDs *[]int  `json:"protected_branch_ids,omitempty"`
+}
+
+var _ = registerResource("gitlab_group_approval_rule", func() *schema.Resource {
+	var validReportTypeValues = []string{
+		"license_scanning",
+		"code_coverage",
+	}
+	return &schema.Resource{
+		Description: `The ` + "`" + `gitlab_group_approval_rule` + "`" + ` resource allows to manage the lifecycle of a group-level approval rule,
+which applies to all projects of the group.
+
+-> This resource requires a GitLab Enterprise instance with GitLab ` + gitlabFeatureMinVersions["group_approval_rules"] + ` or newer,
+where the ` + "`approval_group_rules`" + ` feature flag is enabled.
+
+**Upstream API**: [GitLab REST API docs](https://docs.gitlab.com/ee/api/merge_request_approvals.html#create-group-level-approval-rules)`,
+
+		CreateContext: resourceGitlabGroupApprovalRuleCreate,
+		ReadContext:   resourceGitlabGroupApprovalRuleRead,
+		UpdateContext: resourceGitlabGroupApprovalRuleUpdate,
+		DeleteContext: resourceGitlabGroupApprovalRuleDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		CustomizeDiff: customizeDiffGroupApprovalRule,
+		Schema: map[string]*schema.Schema{
+			"group": {
+				Description: "The name or id of the group to add the approval rules.",
+				Type:        schema.TypeString,
+				ForceNew:    true,
+				Required:    true,
+			},
+			"name": {
+				Description: "The name of the approval rule.",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"approvals_required": {
+				Description: "The number of approvals required for this rule.",
+				Type:        schema.TypeInt,
+				Required:    true,
+			},
+			"rule_type": {
+				Description: "The type of rule, e.g. `regular` or `report_approver` for rules with a `report_type`.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"report_type": {
+				Description:      fmt.Sprintf("The report type required for the rule, which makes it a `report_approver` rule. Valid values are %s.", renderValueListForDocs(validReportTypeValues)),
+				Type:             schema.TypeString,
+				ForceNew:         true,
+				Optional:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice(validReportTypeValues, false)),
+			},
+			"user_ids": {
+				Description: "A list of specific User IDs to add to the list of approvers.",
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeInt},
+				Set:         schema.HashInt,
+			},
+			"group_ids": {
+				Description: "A list of group IDs whose members can approve of the merge request.",
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeInt},
+				Set:         schema.HashInt,
+			},
+			"protected_branch_ids": {
+				Description: "A list of protected branch IDs (not branch names) for which the rule applies.",
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeInt},
+				Set:         schema.HashInt,
+			},
+		},
+	}
+})
+
+// customizeDiffGroupApprovalRule fails the plan of a new rule if the GitLab instance doesn't support
+// group-level approval rules, instead of failing the apply with a plain 404.
+func customizeDiffGroupApprovalRule(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() != "" {
+		return nil
+	}
+
+	supported, err := meta.(*providerMeta).supportsFeature("group_approval_rules")
+	if err != nil {
+		return err
+	}
+	if !supported {
+		return fmt.Errorf("group-level approval rules require GitLab %s or newer", gitlabFeatureMinVersions["group_approval_rules"])
+	}
+	return nil
+}
+
+func resourceGitlabGroupApprovalRuleCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	options := groupApprovalRuleOptions{
+		Name:               gitlab.String(d.Get("name").(string)),
+		ApprovalsRequired:  gitlab.Int(d.Get("approvals_required").(int)),
+		UserIDs:            expandApproverIds(d.Get("user_ids")),
+		GroupIDs:           expandApproverIds(d.Get("group_ids")),
+		ProtectedBranchIDs: expandProtectedBranchIDs(d.Get("protected_branch_ids")),
+	}
+
+	if v, ok := d.GetOk("report_type"); ok {
+		options.RuleType = gitlab.String("report_approver")
+		options.ReportType = gitlab.String(v.(string))
+	}
+
+	group := d.Get("group").(string)
+
+	log.Printf("[DEBUG] Group %s create gitlab group-level rule %+v", group, options)
+
+	client := meta.(*providerMeta).client
+
+	result, _, err := gitlabAPIRequest(ctx, client, http.MethodPost, fmt.Sprintf("groups/%s/approval_rules", url.PathEscape(group)), nil, options)
+	if err != nil {
+		return meta.(*providerMeta).premiumDiagnostics(err, "failed to create approval rule of group %s", group)
+	}
+
+	var rule groupApprovalRule
+	if err := json.Unmarshal(result, &rule); err != nil {
+		return diag.Errorf("failed to decode gitlab group-level rule of group %s: %v", group, err)
+	}
+
+	ruleIDString := strconv.Itoa(rule.ID)
+
+	d.SetId(buildTwoPartID(&group, &ruleIDString))
+
+	return resourceGitlabGroupApprovalRuleRead(ctx, d, meta)
+}
+
+func resourceGitlabGroupApprovalRuleRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Printf("[DEBUG] read gitlab group-level rule %s", d.Id())
+
+	groupID, _, err := parseTwoPartID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	rule, err := getGroupApprovalRuleByID(ctx, meta.(*providerMeta).client, d.Id())
+	if err != nil {
+		if errors.Is(err, errApprovalRuleNotFound) || is404(err) {
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+
+	d.Set("group", groupID)
+	d.Set("name", rule.Name)
+	d.Set("approvals_required", rule.ApprovalsRequired)
+	d.Set("rule_type", rule.RuleType)
+	d.Set("report_type", rule.ReportType)
+
+	if err := d.Set("group_ids", flattenApprovalRuleGroupIDs(rule.Groups)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("user_ids", flattenApprovalRuleUserIDs(rule.Users)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("protected_branch_ids", flattenProtectedBranchIDs(rule.ProtectedBranches)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceGitlabGroupApprovalRuleUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	groupID, ruleID, err := parseTwoPartID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	ruleIDInt, err := strconv.Atoi(ruleID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	options := groupApprovalRuleOptions{
+		Name:               gitlab.String(d.Get("name").(string)),
+		ApprovalsRequired:  gitlab.Int(d.Get("approvals_required").(int)),
+		UserIDs:            expandApproverIds(d.Get("user_ids")),
+		GroupIDs:           expandApproverIds(d.Get("group_ids")),
+		ProtectedBranchIDs: expandProtectedBranchIDs(d.Get("protected_branch_ids")),
+	}
+
+	log.Printf("[DEBUG] Group %s update gitlab group-level approval rule %s", groupID, *options.Name)
+
+	client := meta.(*providerMeta).client
+
+	_, _, err = gitlabAPIRequest(ctx, client, http.MethodPut, fmt.Sprintf("groups/%s/approval_rules/%d", url.PathEscape(groupID), ruleIDInt), nil, options)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceGitlabGroupApprovalRuleRead(ctx, d, meta)
+}
+
+func resourceGitlabGroupApprovalRuleDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	groupID, ruleID, err := parseTwoPartID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	ruleIDInt, err := strconv.Atoi(ruleID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Printf("[DEBUG] Group %s delete gitlab group-level approval rule %d", groupID, ruleIDInt)
+
+	client := meta.(*providerMeta).client
+
+	_, _, err = gitlabAPIRequest(ctx, client, http.MethodDelete, fmt.Sprintf("groups/%s/approval_rules/%d", url.PathEscape(groupID), ruleIDInt), nil, nil)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+// getGroupApprovalRuleByID checks the list of group rules and finds the one that matches our rule ID.
+func getGroupApprovalRuleByID(ctx context.Context, client *gitlab.Client, id string) (*groupApprovalRule, error) {
+	groupID, ruleID, err := parseTwoPartID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	ruleIDInt, err := strconv.Atoi(ruleID)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Printf("[DEBUG] read approval rules for group %s", groupID)
+
+	rules, err := getGroupApprovalRules(ctx, client, groupID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, r := range rules {
+		if r.ID == ruleIDInt {
+			log.Printf("[DEBUG] found group-level rule %+v", r)
+			return r, nil
+		}
+	}
+
+	return nil, errApprovalRuleNotFound
+}
+
+// getGroupApprovalRules returns all approval rules of a group.
+func getGroupApprovalRules(ctx context.Context, client *gitlab.Client, groupID string) ([]*groupApprovalRule, error) {
+	result, err := gitlabAPIGetAllPages(ctx, client, fmt.Sprintf("groups/%s/approval_rules", url.PathEscape(groupID)), nil, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	var rules []*groupApprovalRule
+	if err := json.Unmarshal(result, &rules); err != nil {
+		return nil, fmt.Errorf("failed to decode approval rules of group %s: %w", groupID, err)
+	}
+	return rules, nil
+}
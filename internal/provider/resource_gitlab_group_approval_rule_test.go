@@ -0,0 +1,195 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccGitLabGroupApprovalRule_Basic(t *testing.T) {
+	// Set up groups and users to use in the test.
+
+	testAccCheck(t)
+	testAccCheckEE(t)
+	testAccCheckGitLabVersionAtLeast(t, gitlabFeatureMinVersions["group_approval_rules"])
+
+	groups := testAccCreateGroups(t, 3)
+	group := groups[0]
+	groupUsers := testAccCreateUsers(t, 2)
+	group1Users := testAccCreateUsers(t, 1)
+	group2Users := testAccCreateUsers(t, 1)
+
+	testAccAddGroupMembers(t, group.ID, groupUsers) // Users must belong to the group for rules to work.
+	testAccAddGroupMembers(t, groups[1].ID, group1Users)
+	testAccAddGroupMembers(t, groups[2].ID, group2Users)
+
+	// Terraform test starts here.
+
+	var groupApprovalRule groupApprovalRule
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: providerFactories,
+		CheckDestroy:      testAccCheckGitlabGroupApprovalRuleDestroy(group.ID),
+		Steps: []resource.TestStep{
+			// Create rule
+			{
+				Config: testAccGitlabGroupApprovalRuleConfig_Basic(group.ID, 3, groupUsers[0].ID, groups[1].ID),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckGitlabGroupApprovalRuleExists("gitlab_group_approval_rule.foo", &groupApprovalRule),
+					testAccCheckGitlabGroupApprovalRuleAttributes(&groupApprovalRule, &testAccGitlabGroupApprovalRuleExpectedAttributes{
+						Name:              "foo",
+						ApprovalsRequired: 3,
+						RuleType:          "regular",
+						UserIDs:           []int{groupUsers[0].ID},
+						GroupIDs:          []int{groups[1].ID},
+					}),
+				),
+			},
+			// Update rule
+			{
+				Config: testAccGitlabGroupApprovalRuleConfig_Basic(group.ID, 2, groupUsers[1].ID, groups[2].ID),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckGitlabGroupApprovalRuleExists("gitlab_group_approval_rule.foo", &groupApprovalRule),
+					testAccCheckGitlabGroupApprovalRuleAttributes(&groupApprovalRule, &testAccGitlabGroupApprovalRuleExpectedAttributes{
+						Name:              "foo",
+						ApprovalsRequired: 2,
+						RuleType:          "regular",
+						UserIDs:           []int{groupUsers[1].ID},
+						GroupIDs:          []int{groups[2].ID},
+					}),
+				),
+			},
+			// Verify import
+			{
+				ResourceName:      "gitlab_group_approval_rule.foo",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccGitLabGroupApprovalRule_ReportType(t *testing.T) {
+	testAccCheck(t)
+	testAccCheckEE(t)
+	testAccCheckGitLabVersionAtLeast(t, gitlabFeatureMinVersions["group_approval_rules"])
+
+	group := testAccCreateGroups(t, 1)[0]
+
+	var groupApprovalRule groupApprovalRule
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: providerFactories,
+		CheckDestroy:      testAccCheckGitlabGroupApprovalRuleDestroy(group.ID),
+		Steps: []resource.TestStep{
+			// Create rule
+			{
+				Config: testAccGitlabGroupApprovalRuleConfig_ReportType(group.ID, "code_coverage"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckGitlabGroupApprovalRuleExists("gitlab_group_approval_rule.bar", &groupApprovalRule),
+					testAccCheckGitlabGroupApprovalRuleAttributes(&groupApprovalRule, &testAccGitlabGroupApprovalRuleExpectedAttributes{
+						Name:              "Coverage-Check",
+						ApprovalsRequired: 1,
+						RuleType:          "report_approver",
+						ReportType:        "code_coverage",
+					}),
+				),
+			},
+			// Re-create rule
+			{
+				Config: testAccGitlabGroupApprovalRuleConfig_ReportType(group.ID, "license_scanning"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckGitlabGroupApprovalRuleExists("gitlab_group_approval_rule.bar", &groupApprovalRule),
+					testAccCheckGitlabGroupApprovalRuleAttributes(&groupApprovalRule, &testAccGitlabGroupApprovalRuleExpectedAttributes{
+						Name:              "Coverage-Check",
+						ApprovalsRequired: 1,
+						RuleType:          "report_approver",
+						ReportType:        "license_scanning",
+					}),
+				),
+			},
+			// Verify import
+			{
+				ResourceName:      "gitlab_group_approval_rule.bar",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+type testAccGitlabGroupApprovalRuleExpectedAttributes struct {
+	Name              string
+	ApprovalsRequired int
+	RuleType          string
+	ReportType        string
+	UserIDs           []int
+	GroupIDs          []int
+}
+
+func testAccCheckGitlabGroupApprovalRuleAttributes(got *groupApprovalRule, want *testAccGitlabGroupApprovalRuleExpectedAttributes) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		return InterceptGomegaFailure(func() {
+			Expect(got.Name).To(Equal(want.Name), "name")
+			Expect(got.ApprovalsRequired).To(Equal(want.ApprovalsRequired), "approvals_required")
+			Expect(got.RuleType).To(Equal(want.RuleType), "rule_type")
+			Expect(got.ReportType).To(Equal(want.ReportType), "report_type")
+			Expect(flattenApprovalRuleUserIDs(got.Users)).To(ConsistOf(want.UserIDs), "users")
+			Expect(flattenApprovalRuleGroupIDs(got.Groups)).To(ConsistOf(want.GroupIDs), "groups")
+		})
+	}
+}
+
+func testAccGitlabGroupApprovalRuleConfig_Basic(group, approvals, userID, groupID int) string {
+	return fmt.Sprintf(`
+resource "gitlab_group_approval_rule" "foo" {
+  group              = %d
+  name               = "foo"
+  approvals_required = %d
+  user_ids           = [%d]
+  group_ids          = [%d]
+}`, group, approvals, userID, groupID)
+}
+
+func testAccGitlabGroupApprovalRuleConfig_ReportType(group int, reportType string) string {
+	return fmt.Sprintf(`
+resource "gitlab_group_approval_rule" "bar" {
+  group              = %d
+  name               = "Coverage-Check"
+  approvals_required = 1
+  report_type        = "%s"
+}`, group, reportType)
+}
+
+func testAccCheckGitlabGroupApprovalRuleExists(n string, groupApprovalRule *groupApprovalRule) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not Found: %s", n)
+		}
+
+		rule, err := getGroupApprovalRuleByID(context.Background(), testGitlabClient, rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		*groupApprovalRule = *rule
+		return nil
+	}
+}
+
+func testAccCheckGitlabGroupApprovalRuleDestroy(gid int) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		return InterceptGomegaFailure(func() {
+			rules, err := getGroupApprovalRules(context.Background(), testGitlabClient, strconv.Itoa(gid))
+			Expect(err).To(BeNil())
+			Expect(rules).To(BeEmpty())
+		})
+	}
+}
@@ -0,0 +1,277 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	gitlab "github.com/xanzy/go-gitlab"
+)
+
+// groupMRApprovalSetting is a setting of the merge request approval settings of a group.
+type groupMRApprovalSetting struct {
+	Value         bool   `json:"value"`
+	Locked        bool   `json:"locked"`
+	InheritedFrom string `json:"inherited_from"`
+}
+
+// groupLevelMRApprovalsAttributes maps the attributes of the resource, which match the ones of the
+// `gitlab_project_level_mr_approvals` resource, to the group approval settings.
+// Some of the settings have the inverse meaning of their attribute.
+var groupLevelMRApprovalsAttributes = []struct {
+	Attribute    string
+	Setting      string
+	Inverted     bool
+	DefaultValue bool
+}{
+	{Attribute: "reset_approvals_on_push", Setting: "retain_approvals_on_push", Inverted: true, DefaultValue: true},
+	{Attribute: "disable_overriding_approvers_per_merge_request", Setting: "allow_overrides_to_approver_list_per_merge_request", Inverted: true, DefaultValue: false},
+	{Attribute: "merge_requests_author_approval", Setting: "allow_author_approval", Inverted: false, DefaultValue: false},
+	{Attribute: "merge_requests_disable_committers_approval", Setting: "allow_committer_approval", Inverted: true, DefaultValue: false},
+	{Attribute: "require_password_to_approve", Setting: "require_password_to_approve", Inverted: false, DefaultValue: false},
+}
+
+var _ = registerResource("gitlab_group_level_mr_approvals", func() *schema.Resource {
+	return &schema.Resource{
+		Description: `The ` + "`" + `gitlab_group_level_mr_approvals` + "`" + ` resource allows to manage the merge request approval settings of a group,
+which apply to all projects of the group.
+
+Settings which are locked by the instance or a parent group can't be changed, see ` + "`locked_attributes`" + `.
+Destroying this resource resets the settings to their defaults.
+
+-> This resource requires a GitLab Enterprise instance.
+
+**Upstream API**: [GitLab REST API docs](https://docs.gitlab.com/ee/api/merge_request_approvals.html#group-level-mr-approvals)`,
+
+		CreateContext: resourceGitlabGroupLevelMRApprovalsCreate,
+		ReadContext:   resourceGitlabGroupLevelMRApprovalsRead,
+		UpdateContext: resourceGitlabGroupLevelMRApprovalsUpdate,
+		DeleteContext: resourceGitlabGroupLevelMRApprovalsDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		CustomizeDiff: customizeDiffGroupLevelMRApprovalsLocked,
+		Schema: map[string]*schema.Schema{
+			"group": {
+				Description: "The ID or full path of the group to change MR approval settings.",
+				Type:        schema.TypeString,
+				ForceNew:    true,
+				Required:    true,
+			},
+			"reset_approvals_on_push": {
+				Description: "Set to `true` if you want to remove all approvals in a merge request when new commits are pushed to its source branch. Default is `true`.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+			},
+			"disable_overriding_approvers_per_merge_request": {
+				Description: "Set to `true` if you want to prevent users from editing the approval rules in merge requests.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+			},
+			"merge_requests_author_approval": {
+				Description: "Set to `true` if you want to allow merge request authors to self-approve merge requests.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+			},
+			"merge_requests_disable_committers_approval": {
+				Description: "Set to `true` if you want to prevent approval of merge requests by merge request committers.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+			},
+			"require_password_to_approve": {
+				Description: "Set to `true` if you want to require authentication when approving a merge request.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+			},
+			"locked_attributes": {
+				Description: "The attributes which are locked by the instance or a parent group and thus can't be changed.",
+				Type:        schema.TypeSet,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"inherited_from": {
+				Description: "The attributes which are inherited, mapped to where they are inherited from, e.g. `instance` or `group`.",
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+})
+
+func resourceGitlabGroupLevelMRApprovalsCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+	group := d.Get("group").(string)
+
+	settings, err := resourceGitlabGroupLevelMRApprovalsGet(ctx, client, group)
+	if err != nil {
+		return meta.(*providerMeta).premiumDiagnostics(err, "couldn't read approval settings of group %s", group)
+	}
+
+	// Locked settings are not sent, because they can't be changed.
+	options := make(map[string]bool)
+	for _, a := range groupLevelMRApprovalsAttributes {
+		value := d.Get(a.Attribute).(bool) != a.Inverted
+		if setting := settings[a.Setting]; setting.Locked {
+			if setting.Value != value {
+				return diag.Errorf("`%s` is locked by the instance or a parent group and can't be changed", a.Attribute)
+			}
+			continue
+		}
+		options[a.Setting] = value
+	}
+
+	log.Printf("[DEBUG] Creating MR approval settings for group %s", group)
+
+	if err := resourceGitlabGroupLevelMRApprovalsPut(ctx, client, group, options); err != nil {
+		return diag.Errorf("couldn't create approval settings of group %s: %v", group, err)
+	}
+
+	d.SetId(group)
+	return resourceGitlabGroupLevelMRApprovalsRead(ctx, d, meta)
+}
+
+func resourceGitlabGroupLevelMRApprovalsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+
+	log.Printf("[DEBUG] Reading MR approval settings for group %s", d.Id())
+
+	settings, err := resourceGitlabGroupLevelMRApprovalsGet(ctx, client, d.Id())
+	if err != nil {
+		if is404(err) {
+			log.Printf("[DEBUG] gitlab group approval settings not found for group %s", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("couldn't read approval settings of group %s: %v", d.Id(), err)
+	}
+
+	d.Set("group", d.Id())
+
+	values, locked, inheritedFrom := flattenGroupLevelMRApprovals(settings)
+	for attribute, value := range values {
+		d.Set(attribute, value)
+	}
+
+	if err := d.Set("locked_attributes", locked); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("inherited_from", inheritedFrom); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceGitlabGroupLevelMRApprovalsUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+
+	// Only changed settings are sent, because locked settings can't be changed.
+	options := make(map[string]bool)
+	for _, a := range groupLevelMRApprovalsAttributes {
+		if d.HasChange(a.Attribute) {
+			options[a.Setting] = d.Get(a.Attribute).(bool) != a.Inverted
+		}
+	}
+
+	log.Printf("[DEBUG] Updating MR approval settings for group %s", d.Id())
+
+	if err := resourceGitlabGroupLevelMRApprovalsPut(ctx, client, d.Id(), options); err != nil {
+		return diag.Errorf("couldn't update approval settings of group %s: %v", d.Id(), err)
+	}
+
+	return resourceGitlabGroupLevelMRApprovalsRead(ctx, d, meta)
+}
+
+func resourceGitlabGroupLevelMRApprovalsDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+
+	locked := make(map[string]bool)
+	for _, attribute := range d.Get("locked_attributes").(*schema.Set).List() {
+		locked[attribute.(string)] = true
+	}
+
+	options := make(map[string]bool)
+	for _, a := range groupLevelMRApprovalsAttributes {
+		if !locked[a.Attribute] {
+			options[a.Setting] = a.DefaultValue != a.Inverted
+		}
+	}
+
+	log.Printf("[DEBUG] Resetting MR approval settings for group %s", d.Id())
+
+	if err := resourceGitlabGroupLevelMRApprovalsPut(ctx, client, d.Id(), options); err != nil && !is404(err) {
+		return diag.Errorf("couldn't reset approval settings of group %s: %v", d.Id(), err)
+	}
+
+	return nil
+}
+
+func resourceGitlabGroupLevelMRApprovalsGet(ctx context.Context, client *gitlab.Client, group string) (map[string]groupMRApprovalSetting, error) {
+	result, _, err := gitlabAPIRequest(ctx, client, http.MethodGet, fmt.Sprintf("groups/%s/merge_request_approval_setting", url.PathEscape(group)), nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var settings map[string]groupMRApprovalSetting
+	if err := json.Unmarshal(result, &settings); err != nil {
+		return nil, fmt.Errorf("failed to decode approval settings: %w", err)
+	}
+	return settings, nil
+}
+
+func resourceGitlabGroupLevelMRApprovalsPut(ctx context.Context, client *gitlab.Client, group string, options map[string]bool) error {
+	if len(options) == 0 {
+		return nil
+	}
+
+	_, _, err := gitlabAPIRequest(ctx, client, http.MethodPut, fmt.Sprintf("groups/%s/merge_request_approval_setting", url.PathEscape(group)), nil, options)
+	return err
+}
+
+// flattenGroupLevelMRApprovals returns the attribute values of the given group approval settings,
+// the attributes which are locked and the attributes which are inherited, mapped to where they are inherited from.
+func flattenGroupLevelMRApprovals(settings map[string]groupMRApprovalSetting) (map[string]bool, []string, map[string]string) {
+	values := make(map[string]bool)
+	var locked []string
+	inheritedFrom := make(map[string]string)
+
+	for _, a := range groupLevelMRApprovalsAttributes {
+		setting, ok := settings[a.Setting]
+		if !ok {
+			continue
+		}
+
+		values[a.Attribute] = setting.Value != a.Inverted
+		if setting.Locked {
+			locked = append(locked, a.Attribute)
+		}
+		if setting.InheritedFrom != "" {
+			inheritedFrom[a.Attribute] = setting.InheritedFrom
+		}
+	}
+
+	return values, locked, inheritedFrom
+}
+
+// customizeDiffGroupLevelMRApprovalsLocked fails the plan if a locked attribute is changed,
+// which would otherwise only fail on apply.
+func customizeDiffGroupLevelMRApprovalsLocked(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() == "" {
+		return nil
+	}
+
+	for _, attribute := range d.Get("locked_attributes").(*schema.Set).List() {
+		if d.HasChange(attribute.(string)) {
+			return fmt.Errorf("`%s` is locked by the instance or a parent group and can't be changed", attribute)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,121 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccGitlabGroupLevelMRApprovals_basic(t *testing.T) {
+	testAccCheck(t)
+	testAccCheckEE(t)
+
+	group := testAccCreateGroups(t, 1)[0]
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: providerFactories,
+		CheckDestroy:      testAccCheckGitlabGroupLevelMRApprovalsDestroy(group.ID),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccGitlabGroupLevelMRApprovalsConfig(group.ID, true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckGitlabGroupLevelMRApprovalsValues(group.ID, map[string]bool{
+						"retain_approvals_on_push":                           false,
+						"allow_overrides_to_approver_list_per_merge_request": false,
+						"allow_author_approval":                              true,
+						"allow_committer_approval":                           false,
+						"require_password_to_approve":                        true,
+					}),
+					resource.TestCheckResourceAttr("gitlab_group_level_mr_approvals.foo", "locked_attributes.#", "0"),
+				),
+			},
+			{
+				Config: testAccGitlabGroupLevelMRApprovalsConfig(group.ID, false),
+				Check: testAccCheckGitlabGroupLevelMRApprovalsValues(group.ID, map[string]bool{
+					"retain_approvals_on_push":                           true,
+					"allow_overrides_to_approver_list_per_merge_request": true,
+					"allow_author_approval":                              false,
+					"allow_committer_approval":                           true,
+					"require_password_to_approve":                        false,
+				}),
+			},
+			{
+				ResourceName:      "gitlab_group_level_mr_approvals.foo",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestFlattenGroupLevelMRApprovals(t *testing.T) {
+	values, locked, inheritedFrom := flattenGroupLevelMRApprovals(map[string]groupMRApprovalSetting{
+		"retain_approvals_on_push":                           {Value: true},
+		"allow_overrides_to_approver_list_per_merge_request": {Value: false, Locked: true, InheritedFrom: "instance"},
+		"allow_author_approval":                              {Value: true},
+		"allow_committer_approval":                           {Value: true},
+		"require_password_to_approve":                        {Value: false},
+	})
+
+	expectedValues := map[string]bool{
+		"reset_approvals_on_push":                        false,
+		"disable_overriding_approvers_per_merge_request": true,
+		"merge_requests_author_approval":                 true,
+		"merge_requests_disable_committers_approval":     false,
+		"require_password_to_approve":                    false,
+	}
+	if !reflect.DeepEqual(values, expectedValues) {
+		t.Fatalf("got values %v, expected %v", values, expectedValues)
+	}
+	if !reflect.DeepEqual(locked, []string{"disable_overriding_approvers_per_merge_request"}) {
+		t.Fatalf("got locked attributes %v", locked)
+	}
+	if !reflect.DeepEqual(inheritedFrom, map[string]string{"disable_overriding_approvers_per_merge_request": "instance"}) {
+		t.Fatalf("got inherited attributes %v", inheritedFrom)
+	}
+}
+
+func testAccCheckGitlabGroupLevelMRApprovalsValues(groupID int, want map[string]bool) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		settings, err := resourceGitlabGroupLevelMRApprovalsGet(context.Background(), testGitlabClient, strconv.Itoa(groupID))
+		if err != nil {
+			return err
+		}
+
+		for setting, value := range want {
+			if settings[setting].Value != value {
+				return fmt.Errorf("got %s %t; want %t", setting, settings[setting].Value, value)
+			}
+		}
+		return nil
+	}
+}
+
+// After destroy, the settings are reset to their defaults.
+func testAccCheckGitlabGroupLevelMRApprovalsDestroy(groupID int) resource.TestCheckFunc {
+	return testAccCheckGitlabGroupLevelMRApprovalsValues(groupID, map[string]bool{
+		"retain_approvals_on_push":                           false,
+		"allow_overrides_to_approver_list_per_merge_request": true,
+		"allow_author_approval":                              false,
+		"allow_committer_approval":                           true,
+		"require_password_to_approve":                        false,
+	})
+}
+
+func testAccGitlabGroupLevelMRApprovalsConfig(groupID int, enabled bool) string {
+	return fmt.Sprintf(`
+resource "gitlab_group_level_mr_approvals" "foo" {
+  group                                          = "%d"
+  reset_approvals_on_push                        = %[2]t
+  disable_overriding_approvers_per_merge_request = %[2]t
+  merge_requests_author_approval                 = %[2]t
+  merge_requests_disable_committers_approval     = %[2]t
+  require_password_to_approve                    = %[2]t
+}
+	`, groupID, enabled)
+}
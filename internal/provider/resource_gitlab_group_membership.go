@@ -59,7 +59,7 @@ var _ = registerResource("gitlab_group_membership", func() *schema.Resource {
 })
 
 func resourceGitlabGroupMembershipCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 
 	userId := d.Get("user_id").(int)
 	groupId := d.Get("group_id").(string)
@@ -83,7 +83,7 @@ func resourceGitlabGroupMembershipCreate(ctx context.Context, d *schema.Resource
 }
 
 func resourceGitlabGroupMembershipRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 	id := d.Id()
 	log.Printf("[DEBUG] read gitlab group groupMember %s", id)
 
@@ -119,7 +119,7 @@ func groupIdAndUserIdFromId(id string) (string, int, error) {
 }
 
 func resourceGitlabGroupMembershipUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 
 	userId := d.Get("user_id").(int)
 	groupId := d.Get("group_id").(string)
@@ -141,7 +141,7 @@ func resourceGitlabGroupMembershipUpdate(ctx context.Context, d *schema.Resource
 }
 
 func resourceGitlabGroupMembershipDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 
 	id := d.Id()
 	groupId, userId, err := groupIdAndUserIdFromId(id)
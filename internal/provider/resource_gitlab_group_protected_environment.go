@@ -0,0 +1,135 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// Group-level protected environments protect all environments of a deployment tier.
+var validGroupProtectedEnvironmentTiers = []string{"production", "staging", "testing", "development", "other"}
+
+var _ = registerResource("gitlab_group_protected_environment", func() *schema.Resource {
+	return &schema.Resource{
+		Description: `The ` + "`gitlab_group_protected_environment`" + ` resource allows to manage the lifecycle of a protected environment of a group,
+which restricts who can deploy to the environments of this deployment tier in all projects of the group. Changing any attribute recreates the protection.
+The ` + "`required_approval_count`" + ` requires GitLab ` + gitlabFeatureMinVersions["group_protected_environment_approvals"] + ` or newer.
+
+-> This resource requires a GitLab Enterprise instance.
+
+**Upstream API**: [GitLab REST API docs](https://docs.gitlab.com/ee/api/group_protected_environments.html)`,
+
+		CreateContext: resourceGitlabGroupProtectedEnvironmentCreate,
+		ReadContext:   resourceGitlabGroupProtectedEnvironmentRead,
+		DeleteContext: resourceGitlabGroupProtectedEnvironmentDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		CustomizeDiff: customdiff.All(
+			customizeDiffProtectedEnvironmentDeployAccessLevels,
+			customizeDiffGroupProtectedEnvironmentRequiredApprovalCount,
+		),
+		Schema: constructSchema(map[string]*schema.Schema{
+			"group": {
+				Description:  "The ID or full path of the group which the protected environment is created against.",
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"environment": {
+				Description:  fmt.Sprintf("The deployment tier of the environments to protect. Valid values are: %s.", renderValueListForDocs(validGroupProtectedEnvironmentTiers)),
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice(validGroupProtectedEnvironmentTiers, false),
+			},
+		}, protectedEnvironmentSchema()),
+	}
+})
+
+func resourceGitlabGroupProtectedEnvironmentCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+	group := d.Get("group").(string)
+	environment := d.Get("environment").(string)
+
+	options := expandProtectEnvironmentOptions(d, environment)
+
+	log.Printf("[DEBUG] create gitlab protected environment %q for group %s", environment, group)
+
+	if err := createProtectedEnvironment(ctx, client, protectedEnvironmentsPath("groups", group), options); err != nil {
+		return meta.(*providerMeta).premiumDiagnostics(err, "failed to protect environment %q of group %s", environment, group)
+	}
+
+	d.SetId(buildTwoPartID(&group, &environment))
+
+	return resourceGitlabGroupProtectedEnvironmentRead(ctx, d, meta)
+}
+
+func resourceGitlabGroupProtectedEnvironmentRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+	group, environment, err := parseTwoPartID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Printf("[DEBUG] read gitlab protected environment %q for group %s", environment, group)
+
+	protected, err := getProtectedEnvironment(ctx, client, protectedEnvironmentsPath("groups", group), environment)
+	if err != nil {
+		if is404(err) {
+			log.Printf("[DEBUG] gitlab protected environment %q for group %s not found, removing from state", environment, group)
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("failed to read protected environment %q of group %s: %v", environment, group, err)
+	}
+
+	d.Set("group", group)
+	d.Set("environment", protected.Name)
+	d.Set("required_approval_count", protected.RequiredApprovalCount)
+
+	if err := d.Set("deploy_access_levels", flattenEnvironmentAccessDescriptions(protected.DeployAccessLevels)); err != nil {
+		return diag.Errorf("error setting deploy_access_levels: %v", err)
+	}
+
+	return nil
+}
+
+func resourceGitlabGroupProtectedEnvironmentDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+	group, environment, err := parseTwoPartID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Printf("[DEBUG] delete gitlab protected environment %q for group %s", environment, group)
+
+	if err := deleteProtectedEnvironment(ctx, client, protectedEnvironmentsPath("groups", group), environment); err != nil && !is404(err) {
+		return diag.Errorf("failed to unprotect environment %q of group %s: %v", environment, group, err)
+	}
+
+	return nil
+}
+
+// customizeDiffGroupProtectedEnvironmentRequiredApprovalCount fails the plan if `required_approval_count` is configured,
+// but the GitLab instance doesn't support it for group-level protected environments yet.
+func customizeDiffGroupProtectedEnvironmentRequiredApprovalCount(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if d.GetRawConfig().GetAttr("required_approval_count").IsNull() || !d.HasChange("required_approval_count") {
+		return nil
+	}
+
+	supported, err := meta.(*providerMeta).supportsFeature("group_protected_environment_approvals")
+	if err != nil {
+		return err
+	}
+	if !supported {
+		return fmt.Errorf("`required_approval_count` of group protected environments requires GitLab %s or newer", gitlabFeatureMinVersions["group_protected_environment_approvals"])
+	}
+	return nil
+}
@@ -0,0 +1,54 @@
+package provider
+
+import (
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccGitlabGroupProtectedEnvironment_basic(t *testing.T) {
+	testAccCheck(t)
+	testAccCheckEE(t)
+
+	groups := testAccCreateGroups(t, 2)
+	group := groups[0]
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: providerFactories,
+		CheckDestroy:      testAccCheckGitlabProtectedEnvironmentDestroy("groups", strconv.Itoa(group.ID), "production"),
+		Steps: []resource.TestStep{
+			// Protect deployment tier
+			{
+				Config: testAccGitlabGroupProtectedEnvironmentConfig(group.ID, groups[1].ID),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckGitlabProtectedEnvironmentExists("groups", strconv.Itoa(group.ID), "production"),
+					resource.TestCheckResourceAttr("gitlab_group_protected_environment.this", "deploy_access_levels.#", "2"),
+				),
+			},
+			// Verify import
+			{
+				ResourceName:      "gitlab_group_protected_environment.this",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccGitlabGroupProtectedEnvironmentConfig(groupID int, deployGroupID int) string {
+	return fmt.Sprintf(`
+resource "gitlab_group_protected_environment" "this" {
+  group       = %d
+  environment = "production"
+
+  deploy_access_levels {
+    access_level = "maintainer"
+  }
+
+  deploy_access_levels {
+    group_id = %d
+  }
+}`, groupID, deployGroupID)
+}
@@ -68,7 +68,7 @@ func resourceGitlabGroupShareGroupCreate(ctx context.Context, d *schema.Resource
 		ExpiresAt:   gitlab.String(d.Get("expires_at").(string)),
 	}
 
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 	log.Printf("[DEBUG] create gitlab group share for %d in %s", shareGroupId, groupId)
 
 	_, _, err := client.GroupMembers.ShareWithGroup(groupId, options, gitlab.WithContext(ctx))
@@ -83,7 +83,7 @@ func resourceGitlabGroupShareGroupCreate(ctx context.Context, d *schema.Resource
 }
 
 func resourceGitlabGroupShareGroupRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 	id := d.Id()
 	log.Printf("[DEBUG] read gitlab shared groups %s", id)
 
@@ -128,7 +128,7 @@ func resourceGitlabGroupShareGroupRead(ctx context.Context, d *schema.ResourceDa
 }
 
 func resourceGitlabGroupShareGroupDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 	id := d.Id()
 
 	groupId, sharedGroupId, err := groupIdsFromId(id)
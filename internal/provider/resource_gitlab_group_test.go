@@ -2,7 +2,11 @@ package provider
 
 import (
 	"fmt"
+	"image/color"
 	"net/http"
+	"path/filepath"
+	"regexp"
+	"strconv"
 	"testing"
 	"time"
 
@@ -164,6 +168,8 @@ func TestAccGitlabGroup_nested(t *testing.T) {
 			{
 				Config: testAccGitlabNestedGroupChangeParentConfig(rInt),
 				Check: resource.ComposeTestCheckFunc(
+					// The group is transferred in-place, thus it keeps the id from the previous step.
+					testCheckResourceAttrLazy("gitlab_group.nested_foo", "id", func() string { return strconv.Itoa(nestedGroup.ID) }),
 					testAccCheckGitlabGroupExists("gitlab_group.foo", &group),
 					testAccCheckGitlabGroupExists("gitlab_group.foo2", &group2),
 					testAccCheckGitlabGroupExists("gitlab_group.nested_foo", &nestedGroup),
@@ -184,6 +190,8 @@ func TestAccGitlabGroup_nested(t *testing.T) {
 			{
 				Config: testAccGitlabNestedGroupRemoveParentConfig(rInt),
 				Check: resource.ComposeTestCheckFunc(
+					testCheckResourceAttrLazy("gitlab_group.nested_foo", "id", func() string { return strconv.Itoa(nestedGroup.ID) }),
+					resource.TestCheckResourceAttr("gitlab_group.nested_foo", "full_path", fmt.Sprintf("nfoo-path-%d", rInt)),
 					testAccCheckGitlabGroupExists("gitlab_group.foo", &group),
 					testAccCheckGitlabGroupExists("gitlab_group.foo2", &group2),
 					testAccCheckGitlabGroupExists("gitlab_group.nested_foo", &nestedGroup),
@@ -246,6 +254,113 @@ func TestAccGitlabGroup_disappears(t *testing.T) {
 	})
 }
 
+func TestAccGitlabGroup_resourceDefaults(t *testing.T) {
+	var group gitlab.Group
+	rInt := acctest.RandInt()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: providerFactories,
+		CheckDestroy:      testAccCheckGitlabGroupDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccGitlabGroupResourceDefaultsConfig(rInt, " (managed by Terraform)"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckGitlabGroupExists("gitlab_group.foo", &group),
+					resource.TestCheckResourceAttr("gitlab_group.foo", "description", "Terraform acceptance tests"),
+					resource.TestCheckResourceAttr("gitlab_group.foo", "description_all", "Terraform acceptance tests (managed by Terraform)"),
+					func(s *terraform.State) error {
+						if group.Description != "Terraform acceptance tests (managed by Terraform)" {
+							return fmt.Errorf("expected group description to have the default suffix, got %q", group.Description)
+						}
+						return nil
+					},
+				),
+			},
+			// Changing the default suffix updates the group
+			{
+				Config: testAccGitlabGroupResourceDefaultsConfig(rInt, " (owned by the platform team)"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("gitlab_group.foo", "description", "Terraform acceptance tests"),
+					resource.TestCheckResourceAttr("gitlab_group.foo", "description_all", "Terraform acceptance tests (owned by the platform team)"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccGitlabGroup_deletionProtection(t *testing.T) {
+	var group gitlab.Group
+	rInt := acctest.RandInt()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: providerFactories,
+		CheckDestroy:      testAccCheckGitlabGroupDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccGitlabGroupDeletionProtectionConfig(rInt, true),
+				Check:  testAccCheckGitlabGroupExists("gitlab_group.foo", &group),
+			},
+			// Destroying the group fails while deletion protection is enabled
+			{
+				Config:      testAccGitlabGroupDeletionProtectionConfig(rInt, true),
+				Destroy:     true,
+				ExpectError: regexp.MustCompile("because deletion_protection is enabled"),
+			},
+			{
+				Config: testAccGitlabGroupDeletionProtectionConfig(rInt, true),
+				Check:  testAccCheckGitlabGroupExists("gitlab_group.foo", &group),
+			},
+			// Disable deletion protection, so that the group can be destroyed
+			{
+				Config: testAccGitlabGroupDeletionProtectionConfig(rInt, false),
+				Check:  resource.TestCheckResourceAttr("gitlab_group.foo", "deletion_protection", "false"),
+			},
+		},
+	})
+}
+
+func TestAccGitlabGroup_avatar(t *testing.T) {
+	var group gitlab.Group
+	rInt := acctest.RandInt()
+	avatar := filepath.Join(t.TempDir(), "avatar.png")
+	testAccWriteAvatar(t, avatar, color.RGBA{R: 255, A: 255})
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: providerFactories,
+		CheckDestroy:      testAccCheckGitlabGroupDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccGitlabGroupAvatarConfig(rInt, avatar),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckGitlabGroupExists("gitlab_group.foo", &group),
+					testAccCheckGitlabAvatarHash("gitlab_group.foo", avatar),
+					resource.TestCheckResourceAttrSet("gitlab_group.foo", "avatar_url"),
+				),
+			},
+			// Changing the content of the avatar file uploads it again
+			{
+				PreConfig: func() { testAccWriteAvatar(t, avatar, color.RGBA{B: 255, A: 255}) },
+				Config:    testAccGitlabGroupAvatarConfig(rInt, avatar),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckGitlabAvatarHash("gitlab_group.foo", avatar),
+					resource.TestCheckResourceAttrSet("gitlab_group.foo", "avatar_url"),
+				),
+			},
+			// Removing the avatar
+			{
+				Config: testAccGitlabGroupConfig(rInt),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("gitlab_group.foo", "avatar_hash", ""),
+					resource.TestCheckResourceAttr("gitlab_group.foo", "avatar_url", ""),
+				),
+			},
+		},
+	})
+}
+
 func TestAccGitlabGroup_PreventForkingOutsideGroup(t *testing.T) {
 	var group gitlab.Group
 	rInt := acctest.RandInt()
@@ -451,6 +566,36 @@ resource "gitlab_group" "foo" {
   `, rInt, rInt)
 }
 
+func testAccGitlabGroupDeletionProtectionConfig(rInt int, deletionProtection bool) string {
+	return fmt.Sprintf(`
+resource "gitlab_group" "foo" {
+  name = "foo-name-%[1]d"
+  path = "foo-path-%[1]d"
+  description = "Terraform acceptance tests"
+  deletion_protection = %[2]t
+
+  # So that acceptance tests can be run in a gitlab organization
+  # with no billing
+  visibility_level = "public"
+}
+  `, rInt, deletionProtection)
+}
+
+func testAccGitlabGroupAvatarConfig(rInt int, avatar string) string {
+	return fmt.Sprintf(`
+resource "gitlab_group" "foo" {
+  name = "foo-name-%[1]d"
+  path = "foo-path-%[1]d"
+  description = "Terraform acceptance tests"
+  avatar = %[2]q
+
+  # So that acceptance tests can be run in a gitlab organization
+  # with no billing
+  visibility_level = "public"
+}
+  `, rInt, avatar)
+}
+
 func testAccGitlabGroupUpdateConfig(rInt int, defaultBranchProtection int) string {
 	return fmt.Sprintf(`
 resource "gitlab_group" "foo" {
@@ -605,3 +750,23 @@ resource "gitlab_group" "foo" {
 }
   `, rInt, rInt)
 }
+
+func testAccGitlabGroupResourceDefaultsConfig(rInt int, descriptionSuffix string) string {
+	return fmt.Sprintf(`
+provider "gitlab" {
+  resource_defaults {
+    group_description_suffix = "%s"
+  }
+}
+
+resource "gitlab_group" "foo" {
+  name = "foo-name-%d"
+  path = "foo-path-%d"
+  description = "Terraform acceptance tests"
+
+  # So that acceptance tests can be run in a gitlab organization
+  # with no billing
+  visibility_level = "public"
+}
+  `, descriptionSuffix, rInt, rInt)
+}
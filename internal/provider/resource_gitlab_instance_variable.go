@@ -62,7 +62,7 @@ var _ = registerResource("gitlab_instance_variable", func() *schema.Resource {
 })
 
 func resourceGitlabInstanceVariableCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 
 	key := d.Get("key").(string)
 	value := d.Get("value").(string)
@@ -90,7 +90,7 @@ func resourceGitlabInstanceVariableCreate(ctx context.Context, d *schema.Resourc
 }
 
 func resourceGitlabInstanceVariableRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 
 	key := d.Id()
 
@@ -115,7 +115,7 @@ func resourceGitlabInstanceVariableRead(ctx context.Context, d *schema.ResourceD
 }
 
 func resourceGitlabInstanceVariableUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 
 	key := d.Get("key").(string)
 	value := d.Get("value").(string)
@@ -139,7 +139,7 @@ func resourceGitlabInstanceVariableUpdate(ctx context.Context, d *schema.Resourc
 }
 
 func resourceGitlabInstanceVariableDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 	key := d.Get("key").(string)
 	log.Printf("[DEBUG] Delete gitlab instance level CI variable %s", key)
 
@@ -55,7 +55,7 @@ var _ = registerResource("gitlab_managed_license", func() *schema.Resource {
 })
 
 func resourceGitlabManagedLicenseCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 	project := d.Get("project").(string)
 
 	options := &gitlab.AddManagedLicenseOptions{
@@ -77,7 +77,7 @@ func resourceGitlabManagedLicenseCreate(ctx context.Context, d *schema.ResourceD
 }
 
 func resourceGitlabManagedLicenseDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 	project, licenseId, err := projectIdAndLicenseIdFromId(d.Id())
 	if err != nil {
 		return diag.FromErr(err)
@@ -93,7 +93,7 @@ func resourceGitlabManagedLicenseDelete(ctx context.Context, d *schema.ResourceD
 }
 
 func resourceGitlabManagedLicenseRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 	project, licenseId, err := projectIdAndLicenseIdFromId(d.Id())
 	if err != nil {
 		diag.FromErr(err)
@@ -118,7 +118,7 @@ func resourceGitlabManagedLicenseRead(ctx context.Context, d *schema.ResourceDat
 }
 
 func resourceGitlabManagedLicenseUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 	project, licenseId, err := projectIdAndLicenseIdFromId(d.Id())
 	if err != nil {
 		return diag.FromErr(err)
@@ -55,7 +55,7 @@ var _ = registerResource("gitlab_pipeline_schedule_variable", func() *schema.Res
 })
 
 func resourceGitlabPipelineScheduleVariableCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 	project := d.Get("project").(string)
 	scheduleID := d.Get("pipeline_schedule_id").(int)
 
@@ -78,7 +78,7 @@ func resourceGitlabPipelineScheduleVariableCreate(ctx context.Context, d *schema
 }
 
 func resourceGitlabPipelineScheduleVariableRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 	project := d.Get("project").(string)
 	scheduleID := d.Get("pipeline_schedule_id").(int)
 	pipelineVariableKey := d.Get("key").(string)
@@ -110,7 +110,7 @@ func resourceGitlabPipelineScheduleVariableRead(ctx context.Context, d *schema.R
 }
 
 func resourceGitlabPipelineScheduleVariableUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 	project := d.Get("project").(string)
 	variableKey := d.Get("key").(string)
 	scheduleID := d.Get("pipeline_schedule_id").(int)
@@ -132,7 +132,7 @@ func resourceGitlabPipelineScheduleVariableUpdate(ctx context.Context, d *schema
 }
 
 func resourceGitlabPipelineScheduleVariableDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 	project := d.Get("project").(string)
 	variableKey := d.Get("key").(string)
 	scheduleID := d.Get("pipeline_schedule_id").(int)
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"os"
 	"strconv"
 	"time"
 
@@ -15,6 +16,22 @@ import (
 	gitlab "github.com/xanzy/go-gitlab"
 )
 
+var validContainerExpirationPolicyCadences = []string{"1d", "7d", "14d", "1month", "3month"}
+
+var validContainerExpirationPolicyOlderThans = []string{"7d", "14d", "30d", "90d"}
+
+var validProjectFeatureAccessLevels = []string{"disabled", "private", "enabled"}
+
+// projectFeatureLegacyAttributes maps the `*_access_level` attributes to the legacy boolean attributes
+// which enable or disable the same feature.
+var projectFeatureLegacyAttributes = map[string]string{
+	"issues_access_level":         "issues_enabled",
+	"merge_requests_access_level": "merge_requests_enabled",
+	"builds_access_level":         "pipelines_enabled",
+	"wiki_access_level":           "wiki_enabled",
+	"snippets_access_level":       "snippets_enabled",
+}
+
 var resourceGitLabProjectSchema = map[string]*schema.Schema{
 	"name": {
 		Description: "The name of the project.",
@@ -38,7 +55,7 @@ var resourceGitLabProjectSchema = map[string]*schema.Schema{
 		Computed:    true,
 	},
 	"namespace_id": {
-		Description: "The namespace (group or user) of the project. Defaults to your user.",
+		Description: "The namespace (group or user) of the project. Defaults to your user. Changing it transfers the project to the new namespace in-place, which requires the Owner role for the project.",
 		Type:        schema.TypeInt,
 		Optional:    true,
 		Computed:    true,
@@ -60,6 +77,21 @@ var resourceGitLabProjectSchema = map[string]*schema.Schema{
 		Optional:    true,
 		ForceNew:    true,
 	},
+	"import_file": {
+		Description:   "A local path to a project export archive to create the project from, e.g. downloaded by the `gitlab_project_export` resource. Requires `path` to be set.",
+		Type:          schema.TypeString,
+		Optional:      true,
+		ForceNew:      true,
+		ConflictsWith: []string{"import_url", "forked_from_project_id", "template_name", "template_project_id", "use_custom_template", "initialize_with_readme"},
+		RequiredWith:  []string{"path"},
+	},
+	"forked_from_project_id": {
+		Description:   "The id of the project to fork. The project is created as a fork of this project, which includes its repository. Changing it on an existing project updates the fork relationship instead of recreating the project, setting it to `0` removes the fork relationship. If not configured, the fork relationship of the project is left as is.",
+		Type:          schema.TypeInt,
+		Optional:      true,
+		Computed:      true,
+		ConflictsWith: []string{"import_url", "template_name", "template_project_id", "use_custom_template", "initialize_with_readme"},
+	},
 	"request_access_enabled": {
 		Description: "Allow users to request member access.",
 		Type:        schema.TypeBool,
@@ -67,22 +99,25 @@ var resourceGitLabProjectSchema = map[string]*schema.Schema{
 		Default:     true,
 	},
 	"issues_enabled": {
-		Description: "Enable issue tracking for the project.",
-		Type:        schema.TypeBool,
-		Optional:    true,
-		Default:     true,
+		Description:      "Enable issue tracking for the project.",
+		Type:             schema.TypeBool,
+		Optional:         true,
+		Default:          true,
+		DiffSuppressFunc: suppressDiffIfAccessLevelConfigured("issues_access_level"),
 	},
 	"merge_requests_enabled": {
-		Description: "Enable merge requests for the project.",
-		Type:        schema.TypeBool,
-		Optional:    true,
-		Default:     true,
+		Description:      "Enable merge requests for the project.",
+		Type:             schema.TypeBool,
+		Optional:         true,
+		Default:          true,
+		DiffSuppressFunc: suppressDiffIfAccessLevelConfigured("merge_requests_access_level"),
 	},
 	"pipelines_enabled": {
-		Description: "Enable pipelines for the project.",
-		Type:        schema.TypeBool,
-		Optional:    true,
-		Default:     true,
+		Description:      "Enable pipelines for the project.",
+		Type:             schema.TypeBool,
+		Optional:         true,
+		Default:          true,
+		DiffSuppressFunc: suppressDiffIfAccessLevelConfigured("builds_access_level"),
 	},
 	"approvals_before_merge": {
 		Description: "Number of merge request approvals required for merging. Default is 0.",
@@ -91,16 +126,18 @@ var resourceGitLabProjectSchema = map[string]*schema.Schema{
 		Default:     0,
 	},
 	"wiki_enabled": {
-		Description: "Enable wiki for the project.",
-		Type:        schema.TypeBool,
-		Optional:    true,
-		Default:     true,
+		Description:      "Enable wiki for the project.",
+		Type:             schema.TypeBool,
+		Optional:         true,
+		Default:          true,
+		DiffSuppressFunc: suppressDiffIfAccessLevelConfigured("wiki_access_level"),
 	},
 	"snippets_enabled": {
-		Description: "Enable snippets for the project.",
-		Type:        schema.TypeBool,
-		Optional:    true,
-		Default:     true,
+		Description:      "Enable snippets for the project.",
+		Type:             schema.TypeBool,
+		Optional:         true,
+		Default:          true,
+		DiffSuppressFunc: suppressDiffIfAccessLevelConfigured("snippets_access_level"),
 	},
 	"container_registry_enabled": {
 		Description: "Enable container registry for the project.",
@@ -108,6 +145,61 @@ var resourceGitLabProjectSchema = map[string]*schema.Schema{
 		Optional:    true,
 		Default:     true,
 	},
+	"container_expiration_policy": {
+		Description: "Set the image cleanup policy for the container registry of the project. Settings which are not configured are managed by GitLab.",
+		Type:        schema.TypeList,
+		MaxItems:    1,
+		Optional:    true,
+		Computed:    true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"enabled": {
+					Description: "If true, the policy is enabled.",
+					Type:        schema.TypeBool,
+					Optional:    true,
+					Computed:    true,
+				},
+				"cadence": {
+					Description:  fmt.Sprintf("The cadence of the policy. Valid values are: %s.", renderValueListForDocs(validContainerExpirationPolicyCadences)),
+					Type:         schema.TypeString,
+					Optional:     true,
+					Computed:     true,
+					ValidateFunc: validation.StringInSlice(validContainerExpirationPolicyCadences, false),
+				},
+				"keep_n": {
+					Description:  "The number of images to keep. Valid values are: `1`, `5`, `10`, `25`, `50`, `100`.",
+					Type:         schema.TypeInt,
+					Optional:     true,
+					Computed:     true,
+					ValidateFunc: validation.IntInSlice([]int{1, 5, 10, 25, 50, 100}),
+				},
+				"older_than": {
+					Description:  fmt.Sprintf("The number of days to keep images. Valid values are: %s.", renderValueListForDocs(validContainerExpirationPolicyOlderThans)),
+					Type:         schema.TypeString,
+					Optional:     true,
+					Computed:     true,
+					ValidateFunc: validation.StringInSlice(validContainerExpirationPolicyOlderThans, false),
+				},
+				"name_regex_delete": {
+					Description: "The regular expression to match image names to delete. Set to an empty string to clear it.",
+					Type:        schema.TypeString,
+					Optional:    true,
+					Computed:    true,
+				},
+				"name_regex_keep": {
+					Description: "The regular expression to match image names to keep. Set to an empty string to clear it.",
+					Type:        schema.TypeString,
+					Optional:    true,
+					Computed:    true,
+				},
+				"next_run_at": {
+					Description: "The next time the policy will run. In RFC3339 format.",
+					Type:        schema.TypeString,
+					Computed:    true,
+				},
+			},
+		},
+	},
 	"lfs_enabled": {
 		Description: "Enable LFS for the project.",
 		Type:        schema.TypeBool,
@@ -181,6 +273,13 @@ var resourceGitLabProjectSchema = map[string]*schema.Schema{
 		Elem:        &schema.Schema{Type: schema.TypeString},
 		Set:         schema.HashString,
 	},
+	"tags_all": {
+		Description: "All tags (topics) of the project, including the `project_tags` configured in the `resource_defaults` provider block.",
+		Type:        schema.TypeSet,
+		Computed:    true,
+		Elem:        &schema.Schema{Type: schema.TypeString},
+		Set:         schema.HashString,
+	},
 	"archived": {
 		Description: "Whether the project is in read-only mode (archived). Repositories can be archived/unarchived by toggling this parameter.",
 		Type:        schema.TypeBool,
@@ -307,6 +406,83 @@ var resourceGitLabProjectSchema = map[string]*schema.Schema{
 		Type:        schema.TypeInt,
 		Optional:    true,
 	},
+	"issues_access_level": {
+		Description:  fmt.Sprintf("Set the issues access level. Supersedes `issues_enabled`. Valid values are: %s.", renderValueListForDocs(validProjectFeatureAccessLevels)),
+		Type:         schema.TypeString,
+		Optional:     true,
+		Computed:     true,
+		ValidateFunc: validation.StringInSlice(validProjectFeatureAccessLevels, false),
+	},
+	"repository_access_level": {
+		Description:  fmt.Sprintf("Set the repository access level. Valid values are: %s.", renderValueListForDocs(validProjectFeatureAccessLevels)),
+		Type:         schema.TypeString,
+		Optional:     true,
+		Computed:     true,
+		ValidateFunc: validation.StringInSlice(validProjectFeatureAccessLevels, false),
+	},
+	"merge_requests_access_level": {
+		Description:  fmt.Sprintf("Set the merge requests access level. Supersedes `merge_requests_enabled`. Valid values are: %s.", renderValueListForDocs(validProjectFeatureAccessLevels)),
+		Type:         schema.TypeString,
+		Optional:     true,
+		Computed:     true,
+		ValidateFunc: validation.StringInSlice(validProjectFeatureAccessLevels, false),
+	},
+	"forking_access_level": {
+		Description:  fmt.Sprintf("Set the forking access level. Valid values are: %s.", renderValueListForDocs(validProjectFeatureAccessLevels)),
+		Type:         schema.TypeString,
+		Optional:     true,
+		Computed:     true,
+		ValidateFunc: validation.StringInSlice(validProjectFeatureAccessLevels, false),
+	},
+	"builds_access_level": {
+		Description:  fmt.Sprintf("Set the builds (CI/CD pipelines) access level. Supersedes `pipelines_enabled`. Valid values are: %s.", renderValueListForDocs(validProjectFeatureAccessLevels)),
+		Type:         schema.TypeString,
+		Optional:     true,
+		Computed:     true,
+		ValidateFunc: validation.StringInSlice(validProjectFeatureAccessLevels, false),
+	},
+	"wiki_access_level": {
+		Description:  fmt.Sprintf("Set the wiki access level. Supersedes `wiki_enabled`. Valid values are: %s.", renderValueListForDocs(validProjectFeatureAccessLevels)),
+		Type:         schema.TypeString,
+		Optional:     true,
+		Computed:     true,
+		ValidateFunc: validation.StringInSlice(validProjectFeatureAccessLevels, false),
+	},
+	"snippets_access_level": {
+		Description:  fmt.Sprintf("Set the snippets access level. Supersedes `snippets_enabled`. Valid values are: %s.", renderValueListForDocs(validProjectFeatureAccessLevels)),
+		Type:         schema.TypeString,
+		Optional:     true,
+		Computed:     true,
+		ValidateFunc: validation.StringInSlice(validProjectFeatureAccessLevels, false),
+	},
+	"analytics_access_level": {
+		Description:  fmt.Sprintf("Set the analytics access level. Valid values are: %s.", renderValueListForDocs(validProjectFeatureAccessLevels)),
+		Type:         schema.TypeString,
+		Optional:     true,
+		Computed:     true,
+		ValidateFunc: validation.StringInSlice(validProjectFeatureAccessLevels, false),
+	},
+	"operations_access_level": {
+		Description:  fmt.Sprintf("Set the operations access level. Valid values are: %s.", renderValueListForDocs(validProjectFeatureAccessLevels)),
+		Type:         schema.TypeString,
+		Optional:     true,
+		Computed:     true,
+		ValidateFunc: validation.StringInSlice(validProjectFeatureAccessLevels, false),
+	},
+	"requirements_access_level": {
+		Description:  fmt.Sprintf("Set the requirements access level. [GitLab Ultimate] Valid values are: %s.", renderValueListForDocs(validProjectFeatureAccessLevels)),
+		Type:         schema.TypeString,
+		Optional:     true,
+		Computed:     true,
+		ValidateFunc: validation.StringInSlice(validProjectFeatureAccessLevels, false),
+	},
+	"security_and_compliance_access_level": {
+		Description:  fmt.Sprintf("Set the security and compliance access level. Valid values are: %s.", renderValueListForDocs(validProjectFeatureAccessLevels)),
+		Type:         schema.TypeString,
+		Optional:     true,
+		Computed:     true,
+		ValidateFunc: validation.StringInSlice(validProjectFeatureAccessLevels, false),
+	},
 	"pages_access_level": {
 		Description:  "Enable pages access control",
 		Type:         schema.TypeString,
@@ -317,7 +493,7 @@ var resourceGitLabProjectSchema = map[string]*schema.Schema{
 	// The GitLab API requires that import_url is also set when mirror options are used
 	// Ref: https://github.com/gitlabhq/terraform-provider-gitlab/pull/449#discussion_r549729230
 	"mirror": {
-		Description:  "Enable project pull mirror.",
+		Description:  "Enable project pull mirror. If the pull mirror is managed by the `gitlab_project_pull_mirror` resource instead, add the pull mirror attributes to `ignore_changes`.",
 		Type:         schema.TypeBool,
 		Optional:     true,
 		Default:      false,
@@ -369,6 +545,11 @@ var resourceGitLabProjectSchema = map[string]*schema.Schema{
 		Type:        schema.TypeBool,
 		Optional:    true,
 	},
+	"deletion_protection": {
+		Description: "Set to `true` to make destroying the project fail. It must be set to `false` (and applied) before the project can be deleted or archived on destroy, e.g. to prevent a renamed resource address from deleting a production repository.",
+		Type:        schema.TypeBool,
+		Optional:    true,
+	},
 	"ci_forward_deployment_enabled": {
 		Description: "When a new deployment job starts, skip older deployment jobs that are still pending.",
 		Type:        schema.TypeBool,
@@ -404,17 +585,27 @@ A project can either be created in a group or user namespace.
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
-		Schema: resourceGitLabProjectSchema,
+		// The create timeout includes waiting for an import (by `import_url` or a template) to finish,
+		// the update timeout waiting for a namespace transfer.
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Update: schema.DefaultTimeout(10 * time.Minute),
+			Delete: schema.DefaultTimeout(10 * time.Minute),
+		},
+		Schema: constructSchema(resourceGitLabProjectSchema, avatarSchema("project")),
 		CustomizeDiff: customdiff.All(
 			customdiff.ComputedIf("path_with_namespace", namespaceOrPathChanged),
 			customdiff.ComputedIf("ssh_url_to_repo", namespaceOrPathChanged),
 			customdiff.ComputedIf("http_url_to_repo", namespaceOrPathChanged),
 			customdiff.ComputedIf("web_url", namespaceOrPathChanged),
+			customizeDiffProjectTagsAll,
+			customizeDiffProjectAccessLevels,
+			customizeDiffAvatarHash,
 		),
 	}
 })
 
-func resourceGitlabProjectSetToState(client *gitlab.Client, d *schema.ResourceData, project *gitlab.Project) error {
+func resourceGitlabProjectSetToState(meta *providerMeta, d *schema.ResourceData, project *gitlab.Project) error {
 	d.SetId(fmt.Sprintf("%d", project.ID))
 	d.Set("name", project.Name)
 	d.Set("path", project.Path)
@@ -429,6 +620,9 @@ func resourceGitlabProjectSetToState(client *gitlab.Client, d *schema.ResourceDa
 	d.Set("wiki_enabled", project.WikiEnabled)
 	d.Set("snippets_enabled", project.SnippetsEnabled)
 	d.Set("container_registry_enabled", project.ContainerRegistryEnabled)
+	if err := d.Set("container_expiration_policy", flattenContainerExpirationPolicy(project.ContainerExpirationPolicy)); err != nil {
+		return fmt.Errorf("error setting container_expiration_policy: %v", err)
+	}
 	d.Set("lfs_enabled", project.LFSEnabled)
 	d.Set("visibility_level", string(project.Visibility))
 	d.Set("merge_method", string(project.MergeMethod))
@@ -439,13 +633,18 @@ func resourceGitlabProjectSetToState(client *gitlab.Client, d *schema.ResourceDa
 	d.Set("ssh_url_to_repo", project.SSHURLToRepo)
 	d.Set("http_url_to_repo", project.HTTPURLToRepo)
 	d.Set("web_url", project.WebURL)
+	d.Set("avatar_url", project.AvatarURL)
 	d.Set("runners_token", project.RunnersToken)
 	d.Set("shared_runners_enabled", project.SharedRunnersEnabled)
-	if err := d.Set("tags", project.TagList); err != nil {
+	configuredTags := *stringSetToStringSlice(d.Get("tags").(*schema.Set))
+	if err := d.Set("tags", meta.defaults.removeDefaultProjectTags(project.TagList, configuredTags)); err != nil {
+		return err
+	}
+	if err := d.Set("tags_all", project.TagList); err != nil {
 		return err
 	}
 	d.Set("archived", project.Archived)
-	if supportsSquashOption, err := isGitLabVersionAtLeast(client, "14.1")(); err != nil {
+	if supportsSquashOption, err := meta.supportsFeature("project_squash_option"); err != nil {
 		return err
 	} else if supportsSquashOption {
 		d.Set("squash_option", project.SquashOption)
@@ -454,6 +653,17 @@ func resourceGitlabProjectSetToState(client *gitlab.Client, d *schema.ResourceDa
 	d.Set("printing_merge_request_link_enabled", project.PrintingMergeRequestLinkEnabled)
 	d.Set("packages_enabled", project.PackagesEnabled)
 	d.Set("pages_access_level", string(project.PagesAccessLevel))
+	d.Set("issues_access_level", string(project.IssuesAccessLevel))
+	d.Set("repository_access_level", string(project.RepositoryAccessLevel))
+	d.Set("merge_requests_access_level", string(project.MergeRequestsAccessLevel))
+	d.Set("forking_access_level", string(project.ForkingAccessLevel))
+	d.Set("builds_access_level", string(project.BuildsAccessLevel))
+	d.Set("wiki_access_level", string(project.WikiAccessLevel))
+	d.Set("snippets_access_level", string(project.SnippetsAccessLevel))
+	d.Set("analytics_access_level", string(project.AnalyticsAccessLevel))
+	d.Set("operations_access_level", string(project.OperationsAccessLevel))
+	d.Set("requirements_access_level", string(project.RequirementsAccessLevel))
+	d.Set("security_and_compliance_access_level", string(project.SecurityAndComplianceAccessLevel))
 	d.Set("mirror", project.Mirror)
 	d.Set("mirror_trigger_builds", project.MirrorTriggerBuilds)
 	d.Set("mirror_overwrites_diverged_branches", project.MirrorOverwritesDivergedBranches)
@@ -465,11 +675,17 @@ func resourceGitlabProjectSetToState(client *gitlab.Client, d *schema.ResourceDa
 	d.Set("ci_forward_deployment_enabled", project.CIForwardDeploymentEnabled)
 	d.Set("merge_pipelines_enabled", project.MergePipelinesEnabled)
 	d.Set("merge_trains_enabled", project.MergeTrainsEnabled)
+
+	if project.ForkedFromProject != nil {
+		d.Set("forked_from_project_id", project.ForkedFromProject.ID)
+	} else {
+		d.Set("forked_from_project_id", 0)
+	}
 	return nil
 }
 
 func resourceGitlabProjectCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 
 	options := &gitlab.CreateProjectOptions{
 		Name:                             gitlab.String(d.Get("name").(string)),
@@ -514,7 +730,8 @@ func resourceGitlabProjectCreate(ctx context.Context, d *schema.ResourceData, me
 		options.DefaultBranch = gitlab.String(v.(string))
 	}
 
-	if v, ok := d.GetOk("tags"); ok {
+	// tags_all contains the configured tags and the default tags of the provider, see customizeDiffProjectTagsAll.
+	if v, ok := d.GetOk("tags_all"); ok {
 		options.TagList = stringSetToStringSlice(v.(*schema.Set))
 	}
 
@@ -550,7 +767,65 @@ func resourceGitlabProjectCreate(ctx context.Context, d *schema.ResourceData, me
 		options.CIConfigPath = gitlab.String(v.(string))
 	}
 
-	if supportsSquashOption, err := isGitLabVersionAtLeast(client, "14.1")(); err != nil {
+	if v, ok := d.GetOk("issues_access_level"); ok {
+		options.IssuesAccessLevel = stringToAccessControlValue(v.(string))
+		// The access level supersedes the legacy boolean, see customizeDiffProjectAccessLevels.
+		options.IssuesEnabled = nil
+	}
+
+	if v, ok := d.GetOk("repository_access_level"); ok {
+		options.RepositoryAccessLevel = stringToAccessControlValue(v.(string))
+	}
+
+	if v, ok := d.GetOk("merge_requests_access_level"); ok {
+		options.MergeRequestsAccessLevel = stringToAccessControlValue(v.(string))
+		// The access level supersedes the legacy boolean, see customizeDiffProjectAccessLevels.
+		options.MergeRequestsEnabled = nil
+	}
+
+	if v, ok := d.GetOk("forking_access_level"); ok {
+		options.ForkingAccessLevel = stringToAccessControlValue(v.(string))
+	}
+
+	if v, ok := d.GetOk("builds_access_level"); ok {
+		options.BuildsAccessLevel = stringToAccessControlValue(v.(string))
+		// The access level supersedes the legacy boolean, see customizeDiffProjectAccessLevels.
+		options.JobsEnabled = nil
+	}
+
+	if v, ok := d.GetOk("wiki_access_level"); ok {
+		options.WikiAccessLevel = stringToAccessControlValue(v.(string))
+		// The access level supersedes the legacy boolean, see customizeDiffProjectAccessLevels.
+		options.WikiEnabled = nil
+	}
+
+	if v, ok := d.GetOk("snippets_access_level"); ok {
+		options.SnippetsAccessLevel = stringToAccessControlValue(v.(string))
+		// The access level supersedes the legacy boolean, see customizeDiffProjectAccessLevels.
+		options.SnippetsEnabled = nil
+	}
+
+	if v, ok := d.GetOk("analytics_access_level"); ok {
+		options.AnalyticsAccessLevel = stringToAccessControlValue(v.(string))
+	}
+
+	if v, ok := d.GetOk("operations_access_level"); ok {
+		options.OperationsAccessLevel = stringToAccessControlValue(v.(string))
+	}
+
+	if v, ok := d.GetOk("requirements_access_level"); ok {
+		options.RequirementsAccessLevel = stringToAccessControlValue(v.(string))
+	}
+
+	if v, ok := d.GetOk("security_and_compliance_access_level"); ok {
+		options.SecurityAndComplianceAccessLevel = stringToAccessControlValue(v.(string))
+	}
+
+	if _, ok := d.GetOk("container_expiration_policy"); ok {
+		options.ContainerExpirationPolicyAttributes = expandContainerExpirationPolicyAttributes(d)
+	}
+
+	if supportsSquashOption, err := meta.(*providerMeta).supportsFeature("project_squash_option"); err != nil {
 		return diag.FromErr(err)
 	} else if supportsSquashOption {
 		if v, ok := d.GetOk("squash_option"); ok {
@@ -558,9 +833,27 @@ func resourceGitlabProjectCreate(ctx context.Context, d *schema.ResourceData, me
 		}
 	}
 
-	log.Printf("[DEBUG] create gitlab project %q", *options.Name)
+	var project *gitlab.Project
+	var err error
+	if v, ok := d.GetOk("forked_from_project_id"); ok {
+		log.Printf("[DEBUG] create gitlab project %q as fork of project %d", *options.Name, v.(int))
+
+		project, _, err = client.Projects.ForkProject(v.(int), &gitlab.ForkProjectOptions{
+			Name:        options.Name,
+			Path:        options.Path,
+			NamespaceID: options.NamespaceID,
+			Description: options.Description,
+			Visibility:  options.Visibility,
+		}, gitlab.WithContext(ctx))
+	} else if v, ok := d.GetOk("import_file"); ok {
+		log.Printf("[DEBUG] create gitlab project %q from export file %q", *options.Name, v.(string))
 
-	project, _, err := client.Projects.CreateProject(options, gitlab.WithContext(ctx))
+		project, err = resourceGitlabProjectImportFromFile(ctx, client, v.(string), options)
+	} else {
+		log.Printf("[DEBUG] create gitlab project %q", *options.Name)
+
+		project, _, err = client.Projects.CreateProject(options, gitlab.WithContext(ctx))
+	}
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -569,14 +862,14 @@ func resourceGitlabProjectCreate(ctx context.Context, d *schema.ResourceData, me
 	// is committed to state since we set its ID
 	d.SetId(fmt.Sprintf("%d", project.ID))
 
-	// An import can be triggered by import_url or by creating the project from a template.
+	// An import can be triggered by import_url, by creating the project from a template or an export file or by forking.
 	if project.ImportStatus != "none" {
 		log.Printf("[DEBUG] waiting for project %q import to finish", *options.Name)
 
 		stateConf := &resource.StateChangeConf{
 			Pending: []string{"scheduled", "started"},
 			Target:  []string{"finished"},
-			Timeout: 10 * time.Minute,
+			Timeout: d.Timeout(schema.TimeoutCreate),
 			Refresh: func() (interface{}, string, error) {
 				status, _, err := client.ProjectImportExport.ImportStatus(d.Id(), gitlab.WithContext(ctx))
 				if err != nil {
@@ -598,6 +891,30 @@ func resourceGitlabProjectCreate(ctx context.Context, d *schema.ResourceData, me
 		}
 	}
 
+	// A fork can only be created with a few options, the others are applied once the fork is ready.
+	if _, ok := d.GetOk("forked_from_project_id"); ok {
+		log.Printf("[DEBUG] apply settings to forked project %q", d.Id())
+
+		project, _, err = client.Projects.EditProject(project.ID, deferredEditProjectOptions(options), gitlab.WithContext(ctx))
+		if err != nil {
+			return diag.Errorf("Failed to apply settings to forked project %q: %s", d.Id(), err)
+		}
+	}
+
+	// The same applies to a project imported from an export file, which has the description and visibility of the exported project.
+	if _, ok := d.GetOk("import_file"); ok {
+		log.Printf("[DEBUG] apply settings to imported project %q", d.Id())
+
+		editOptions := deferredEditProjectOptions(options)
+		editOptions.Description = options.Description
+		editOptions.Visibility = options.Visibility
+
+		project, _, err = client.Projects.EditProject(project.ID, editOptions, gitlab.WithContext(ctx))
+		if err != nil {
+			return diag.Errorf("Failed to apply settings to imported project %q: %s", d.Id(), err)
+		}
+	}
+
 	if d.Get("archived").(bool) {
 		// strange as it may seem, this project is created in archived state...
 		if _, _, err := client.Projects.ArchiveProject(d.Id(), gitlab.WithContext(ctx)); err != nil {
@@ -610,7 +927,7 @@ func resourceGitlabProjectCreate(ctx context.Context, d *schema.ResourceData, me
 		if err != nil {
 			if is404(err) {
 				log.Printf("[DEBUG] Failed to edit push rules for project %q: %v", d.Id(), err)
-				return diag.Errorf("Project push rules are not supported in your version of GitLab")
+				return meta.(*providerMeta).premiumDiagnostics(err, "Project push rules are not supported in your version of GitLab")
 			}
 			return diag.Errorf("Failed to edit push rules for project %q: %s", d.Id(), err)
 		}
@@ -743,11 +1060,17 @@ func resourceGitlabProjectCreate(ctx context.Context, d *schema.ResourceData, me
 		}
 	}
 
+	if v, ok := d.GetOk("avatar"); ok {
+		if err := updateAvatar(ctx, client, fmt.Sprintf("projects/%s", d.Id()), v.(string)); err != nil {
+			return diag.Errorf("Could not upload avatar of project %q: %s", d.Id(), err)
+		}
+	}
+
 	return resourceGitlabProjectRead(ctx, d, meta)
 }
 
 func resourceGitlabProjectRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 	log.Printf("[DEBUG] read gitlab project %s", d.Id())
 
 	project, _, err := client.Projects.GetProject(d.Id(), nil, gitlab.WithContext(ctx))
@@ -765,7 +1088,7 @@ func resourceGitlabProjectRead(ctx context.Context, d *schema.ResourceData, meta
 		return nil
 	}
 
-	if err := resourceGitlabProjectSetToState(client, d, project); err != nil {
+	if err := resourceGitlabProjectSetToState(meta.(*providerMeta), d, project); err != nil {
 		return diag.FromErr(err)
 	}
 
@@ -785,7 +1108,7 @@ func resourceGitlabProjectRead(ctx context.Context, d *schema.ResourceData, meta
 }
 
 func resourceGitlabProjectUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 
 	options := &gitlab.EditProjectOptions{}
 	transferOptions := &gitlab.TransferProjectOptions{}
@@ -862,19 +1185,23 @@ func resourceGitlabProjectUpdate(ctx context.Context, d *schema.ResourceData, me
 		options.SharedRunnersEnabled = gitlab.Bool(d.Get("shared_runners_enabled").(bool))
 	}
 
-	if d.HasChange("tags") {
-		options.TagList = stringSetToStringSlice(d.Get("tags").(*schema.Set))
+	if d.HasChange("tags_all") {
+		options.TagList = stringSetToStringSlice(d.Get("tags_all").(*schema.Set))
 	}
 
 	if d.HasChange("container_registry_enabled") {
 		options.ContainerRegistryEnabled = gitlab.Bool(d.Get("container_registry_enabled").(bool))
 	}
 
+	if d.HasChange("container_expiration_policy") {
+		options.ContainerExpirationPolicyAttributes = expandContainerExpirationPolicyAttributes(d)
+	}
+
 	if d.HasChange("lfs_enabled") {
 		options.LFSEnabled = gitlab.Bool(d.Get("lfs_enabled").(bool))
 	}
 
-	if supportsSquashOption, err := isGitLabVersionAtLeast(client, "14.1")(); err != nil {
+	if supportsSquashOption, err := meta.(*providerMeta).supportsFeature("project_squash_option"); err != nil {
 		return diag.FromErr(err)
 	} else if supportsSquashOption && d.HasChange("squash_option") {
 		options.SquashOption = stringToSquashOptionValue(d.Get("squash_option").(string))
@@ -896,6 +1223,60 @@ func resourceGitlabProjectUpdate(ctx context.Context, d *schema.ResourceData, me
 		options.PagesAccessLevel = stringToAccessControlValue(d.Get("pages_access_level").(string))
 	}
 
+	if d.HasChange("issues_access_level") {
+		options.IssuesAccessLevel = stringToAccessControlValue(d.Get("issues_access_level").(string))
+		// The access level supersedes the legacy boolean, see customizeDiffProjectAccessLevels.
+		options.IssuesEnabled = nil
+	}
+
+	if d.HasChange("repository_access_level") {
+		options.RepositoryAccessLevel = stringToAccessControlValue(d.Get("repository_access_level").(string))
+	}
+
+	if d.HasChange("merge_requests_access_level") {
+		options.MergeRequestsAccessLevel = stringToAccessControlValue(d.Get("merge_requests_access_level").(string))
+		// The access level supersedes the legacy boolean, see customizeDiffProjectAccessLevels.
+		options.MergeRequestsEnabled = nil
+	}
+
+	if d.HasChange("forking_access_level") {
+		options.ForkingAccessLevel = stringToAccessControlValue(d.Get("forking_access_level").(string))
+	}
+
+	if d.HasChange("builds_access_level") {
+		options.BuildsAccessLevel = stringToAccessControlValue(d.Get("builds_access_level").(string))
+		// The access level supersedes the legacy boolean, see customizeDiffProjectAccessLevels.
+		options.JobsEnabled = nil
+	}
+
+	if d.HasChange("wiki_access_level") {
+		options.WikiAccessLevel = stringToAccessControlValue(d.Get("wiki_access_level").(string))
+		// The access level supersedes the legacy boolean, see customizeDiffProjectAccessLevels.
+		options.WikiEnabled = nil
+	}
+
+	if d.HasChange("snippets_access_level") {
+		options.SnippetsAccessLevel = stringToAccessControlValue(d.Get("snippets_access_level").(string))
+		// The access level supersedes the legacy boolean, see customizeDiffProjectAccessLevels.
+		options.SnippetsEnabled = nil
+	}
+
+	if d.HasChange("analytics_access_level") {
+		options.AnalyticsAccessLevel = stringToAccessControlValue(d.Get("analytics_access_level").(string))
+	}
+
+	if d.HasChange("operations_access_level") {
+		options.OperationsAccessLevel = stringToAccessControlValue(d.Get("operations_access_level").(string))
+	}
+
+	if d.HasChange("requirements_access_level") {
+		options.RequirementsAccessLevel = stringToAccessControlValue(d.Get("requirements_access_level").(string))
+	}
+
+	if d.HasChange("security_and_compliance_access_level") {
+		options.SecurityAndComplianceAccessLevel = stringToAccessControlValue(d.Get("security_and_compliance_access_level").(string))
+	}
+
 	if d.HasChange("mirror") {
 		options.ImportURL = gitlab.String(d.Get("import_url").(string))
 		options.Mirror = gitlab.Bool(d.Get("mirror").(bool))
@@ -952,11 +1333,59 @@ func resourceGitlabProjectUpdate(ctx context.Context, d *schema.ResourceData, me
 		}
 	}
 
+	// Moving the project to another namespace is an in-place transfer, the project keeps its id.
 	if *transferOptions != (gitlab.TransferProjectOptions{}) {
-		log.Printf("[DEBUG] transferring project %s to namespace %d", d.Id(), transferOptions.Namespace)
+		namespaceID := d.Get("namespace_id").(int)
+		oldPath, _ := d.GetChange("path_with_namespace")
+
+		log.Printf("[DEBUG] transferring project %s to namespace %d", d.Id(), namespaceID)
 		_, _, err := client.Projects.TransferProject(d.Id(), transferOptions, gitlab.WithContext(ctx))
 		if err != nil {
-			return diag.FromErr(err)
+			return transferDiagnostics("project", oldPath.(string), fmt.Sprintf("namespace %d", namespaceID), err)
+		}
+
+		// Wait for the project to show up in the new namespace.
+		stateConf := &resource.StateChangeConf{
+			Pending: []string{"Transferring"},
+			Target:  []string{"Transferred"},
+			Refresh: func() (interface{}, string, error) {
+				out, _, err := client.Projects.GetProject(d.Id(), nil, gitlab.WithContext(ctx))
+				if err != nil {
+					log.Printf("[ERROR] Received error: %#v", err)
+					return out, "Error", err
+				}
+				if out.Namespace != nil && out.Namespace.ID == namespaceID {
+					return out, "Transferred", nil
+				}
+				return out, "Transferring", nil
+			},
+
+			Timeout:    d.Timeout(schema.TimeoutUpdate),
+			MinTimeout: 3 * time.Second,
+		}
+
+		if _, err := stateConf.WaitForStateContext(ctx); err != nil {
+			return diag.Errorf("error waiting for project (%s) to be transferred to namespace %d: %s", d.Id(), namespaceID, err)
+		}
+	}
+
+	// The fork relation is only changed if it's configured, because the attribute is computed for
+	// projects which are forks, e.g. after an import.
+	if d.HasChange("forked_from_project_id") && !d.GetRawConfig().GetAttr("forked_from_project_id").IsNull() {
+		oldForkedFrom, newForkedFrom := d.GetChange("forked_from_project_id")
+
+		if oldForkedFrom.(int) != 0 {
+			log.Printf("[DEBUG] delete fork relation of project %q to project %d", d.Id(), oldForkedFrom.(int))
+			if _, err := client.Projects.DeleteProjectForkRelation(d.Id(), gitlab.WithContext(ctx)); err != nil {
+				return diag.Errorf("Failed to delete fork relation of project %q: %s", d.Id(), err)
+			}
+		}
+
+		if newForkedFrom.(int) != 0 {
+			log.Printf("[DEBUG] create fork relation of project %q to project %d", d.Id(), newForkedFrom.(int))
+			if _, _, err := client.Projects.CreateProjectForkRelation(d.Id(), newForkedFrom.(int), gitlab.WithContext(ctx)); err != nil {
+				return diag.Errorf("Failed to create fork relation of project %q to project %d: %s", d.Id(), newForkedFrom.(int), err)
+			}
 		}
 	}
 
@@ -977,17 +1406,27 @@ func resourceGitlabProjectUpdate(ctx context.Context, d *schema.ResourceData, me
 		if err != nil {
 			if is404(err) {
 				log.Printf("[DEBUG] Failed to get push rules for project %q: %v", d.Id(), err)
-				return diag.Errorf("Project push rules are not supported in your version of GitLab")
+				return meta.(*providerMeta).premiumDiagnostics(err, "Project push rules are not supported in your version of GitLab")
 			}
 			return diag.Errorf("Failed to edit push rules for project %q: %s", d.Id(), err)
 		}
 	}
 
+	if d.HasChange("avatar_hash") {
+		if err := updateAvatar(ctx, client, fmt.Sprintf("projects/%s", d.Id()), d.Get("avatar").(string)); err != nil {
+			return diag.Errorf("Could not update avatar of project %q: %s", d.Id(), err)
+		}
+	}
+
 	return resourceGitlabProjectRead(ctx, d, meta)
 }
 
 func resourceGitlabProjectDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
+
+	if d.Get("deletion_protection").(bool) {
+		return deletionProtectionDiagnostics("project", d.Get("path_with_namespace").(string))
+	}
 
 	if !d.Get("archive_on_destroy").(bool) {
 		log.Printf("[DEBUG] Delete gitlab project %s", d.Id())
@@ -1017,7 +1456,7 @@ func resourceGitlabProjectDelete(ctx context.Context, d *schema.ResourceData, me
 				return out, "Deleting", nil
 			},
 
-			Timeout:    10 * time.Minute,
+			Timeout:    d.Timeout(schema.TimeoutDelete),
 			MinTimeout: 3 * time.Second,
 			Delay:      5 * time.Second,
 		}
@@ -1038,6 +1477,75 @@ func resourceGitlabProjectDelete(ctx context.Context, d *schema.ResourceData, me
 	return nil
 }
 
+// resourceGitlabProjectImportFromFile creates a project from a local export archive.
+// The import runs asynchronously, thus the returned project reports the status of the import.
+func resourceGitlabProjectImportFromFile(ctx context.Context, client *gitlab.Client, path string, options *gitlab.CreateProjectOptions) (*gitlab.Project, error) {
+	archive, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer archive.Close()
+
+	importOptions := &gitlab.ImportFileOptions{
+		Name: options.Name,
+		Path: options.Path,
+	}
+	if options.NamespaceID != nil {
+		importOptions.Namespace = gitlab.String(strconv.Itoa(*options.NamespaceID))
+	}
+
+	status, _, err := client.ProjectImportExport.ImportFromFile(archive, importOptions, gitlab.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	project, _, err := client.Projects.GetProject(status.ID, nil, gitlab.WithContext(ctx))
+	return project, err
+}
+
+// deferredEditProjectOptions returns the options to apply the settings of a project,
+// which are not supported when forking a project or importing it from an export file.
+func deferredEditProjectOptions(options *gitlab.CreateProjectOptions) *gitlab.EditProjectOptions {
+	return &gitlab.EditProjectOptions{
+		RequestAccessEnabled:             options.RequestAccessEnabled,
+		IssuesEnabled:                    options.IssuesEnabled,
+		MergeRequestsEnabled:             options.MergeRequestsEnabled,
+		JobsEnabled:                      options.JobsEnabled,
+		ApprovalsBeforeMerge:             options.ApprovalsBeforeMerge,
+		WikiEnabled:                      options.WikiEnabled,
+		SnippetsEnabled:                  options.SnippetsEnabled,
+		ContainerRegistryEnabled:         options.ContainerRegistryEnabled,
+		LFSEnabled:                       options.LFSEnabled,
+		MergeMethod:                      options.MergeMethod,
+		OnlyAllowMergeIfPipelineSucceeds: options.OnlyAllowMergeIfPipelineSucceeds,
+		OnlyAllowMergeIfAllDiscussionsAreResolved: options.OnlyAllowMergeIfAllDiscussionsAreResolved,
+		AllowMergeOnSkippedPipeline:               options.AllowMergeOnSkippedPipeline,
+		SharedRunnersEnabled:                      options.SharedRunnersEnabled,
+		RemoveSourceBranchAfterMerge:              options.RemoveSourceBranchAfterMerge,
+		PackagesEnabled:                           options.PackagesEnabled,
+		PrintingMergeRequestLinkEnabled:           options.PrintingMergeRequestLinkEnabled,
+		BuildCoverageRegex:                        options.BuildCoverageRegex,
+		CIConfigPath:                              options.CIConfigPath,
+		CIForwardDeploymentEnabled:                options.CIForwardDeploymentEnabled,
+		DefaultBranch:                             options.DefaultBranch,
+		TagList:                                   options.TagList,
+		PagesAccessLevel:                          options.PagesAccessLevel,
+		SquashOption:                              options.SquashOption,
+		ContainerExpirationPolicyAttributes:       options.ContainerExpirationPolicyAttributes,
+		IssuesAccessLevel:                         options.IssuesAccessLevel,
+		RepositoryAccessLevel:                     options.RepositoryAccessLevel,
+		MergeRequestsAccessLevel:                  options.MergeRequestsAccessLevel,
+		ForkingAccessLevel:                        options.ForkingAccessLevel,
+		BuildsAccessLevel:                         options.BuildsAccessLevel,
+		WikiAccessLevel:                           options.WikiAccessLevel,
+		SnippetsAccessLevel:                       options.SnippetsAccessLevel,
+		AnalyticsAccessLevel:                      options.AnalyticsAccessLevel,
+		OperationsAccessLevel:                     options.OperationsAccessLevel,
+		RequirementsAccessLevel:                   options.RequirementsAccessLevel,
+		SecurityAndComplianceAccessLevel:          options.SecurityAndComplianceAccessLevel,
+	}
+}
+
 func editOrAddPushRules(ctx context.Context, client *gitlab.Client, projectID string, d *schema.ResourceData) error {
 	log.Printf("[DEBUG] Editing push rules for project %q", projectID)
 
@@ -1193,6 +1701,114 @@ func flattenProjectPushRules(pushRules *gitlab.ProjectPushRules) (values []map[s
 	}
 }
 
+// expandContainerExpirationPolicyAttributes returns the configured settings of the container expiration policy.
+// Settings which are not configured are omitted, so that GitLab keeps its current (or default) values.
+// The regular expressions can be cleared by setting them to an empty string.
+func expandContainerExpirationPolicyAttributes(d *schema.ResourceData) *gitlab.ContainerExpirationPolicyAttributes {
+	attributes := &gitlab.ContainerExpirationPolicyAttributes{}
+
+	// nolint:staticcheck // SA1019 ignore deprecated GetOkExists
+	// lintignore: XR001 // TODO: replace with alternative for GetOkExists
+	if v, ok := d.GetOkExists("container_expiration_policy.0.enabled"); ok {
+		attributes.Enabled = gitlab.Bool(v.(bool))
+	}
+
+	if v, ok := d.GetOk("container_expiration_policy.0.cadence"); ok {
+		attributes.Cadence = gitlab.String(v.(string))
+	}
+
+	if v, ok := d.GetOk("container_expiration_policy.0.keep_n"); ok {
+		attributes.KeepN = gitlab.Int(v.(int))
+	}
+
+	if v, ok := d.GetOk("container_expiration_policy.0.older_than"); ok {
+		attributes.OlderThan = gitlab.String(v.(string))
+	}
+
+	if v, ok := d.GetOk("container_expiration_policy.0.name_regex_delete"); ok || d.HasChange("container_expiration_policy.0.name_regex_delete") {
+		attributes.NameRegexDelete = gitlab.String(v.(string))
+	}
+
+	if v, ok := d.GetOk("container_expiration_policy.0.name_regex_keep"); ok || d.HasChange("container_expiration_policy.0.name_regex_keep") {
+		attributes.NameRegexKeep = gitlab.String(v.(string))
+	}
+
+	return attributes
+}
+
+func flattenContainerExpirationPolicy(policy *gitlab.ContainerExpirationPolicy) []map[string]interface{} {
+	if policy == nil {
+		return []map[string]interface{}{}
+	}
+
+	values := map[string]interface{}{
+		"enabled":           policy.Enabled,
+		"cadence":           policy.Cadence,
+		"keep_n":            policy.KeepN,
+		"older_than":        policy.OlderThan,
+		"name_regex_delete": policy.NameRegexDelete,
+		"name_regex_keep":   policy.NameRegexKeep,
+		"next_run_at":       "",
+	}
+	if policy.NextRunAt != nil {
+		values["next_run_at"] = policy.NextRunAt.Format(time.RFC3339)
+	}
+
+	return []map[string]interface{}{values}
+}
+
+// customizeDiffProjectAccessLevels resolves conflicts between the `*_access_level` attributes
+// and the legacy booleans which enable or disable the same feature:
+// a changed boolean (without a configured access level) determines the access level,
+// while a configured access level suppresses the diff of an unconfigured boolean, see suppressDiffIfAccessLevelConfigured.
+// Configuring both with contradicting values is an error.
+func customizeDiffProjectAccessLevels(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	config := d.GetRawConfig()
+	if config.IsNull() || !config.IsKnown() {
+		return nil
+	}
+
+	for levelAttr, boolAttr := range projectFeatureLegacyAttributes {
+		if !d.NewValueKnown(levelAttr) || !d.NewValueKnown(boolAttr) {
+			continue
+		}
+
+		levelConfigured := !config.GetAttr(levelAttr).IsNull()
+		boolConfigured := !config.GetAttr(boolAttr).IsNull()
+		level := d.Get(levelAttr).(string)
+		enabled := d.Get(boolAttr).(bool)
+
+		switch {
+		case levelConfigured && boolConfigured:
+			if enabled != (level != "disabled") {
+				return fmt.Errorf("`%s = %t` conflicts with `%s = %q`, remove `%s` from the configuration", boolAttr, enabled, levelAttr, level, boolAttr)
+			}
+		case d.Id() != "" && d.HasChange(boolAttr):
+			newLevel := "disabled"
+			if enabled {
+				newLevel = "enabled"
+			}
+			if err := d.SetNew(levelAttr, newLevel); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// suppressDiffIfAccessLevelConfigured suppresses the diff of a legacy boolean, e.g. `issues_enabled`,
+// if it isn't configured, but the given access level superseding it is.
+func suppressDiffIfAccessLevelConfigured(levelAttr string) schema.SchemaDiffSuppressFunc {
+	return func(k, old, new string, d *schema.ResourceData) bool {
+		config := d.GetRawConfig()
+		if config.IsNull() || !config.IsKnown() {
+			return false
+		}
+		return config.GetAttr(k).IsNull() && !config.GetAttr(levelAttr).IsNull()
+	}
+}
+
 func namespaceOrPathChanged(ctx context.Context, d *schema.ResourceDiff, meta interface{}) bool {
 	return d.HasChange("namespace_id") || d.HasChange("path")
 }
@@ -93,7 +93,7 @@ var _ = registerResource("gitlab_project_access_token", func() *schema.Resource
 })
 
 func resourceGitlabProjectAccessTokenCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 
 	project := d.Get("project").(string)
 	options := &gitlab.CreateProjectAccessTokenOptions{
@@ -134,7 +134,7 @@ func resourceGitlabProjectAccessTokenRead(ctx context.Context, d *schema.Resourc
 		return diag.Errorf("Error parsing ID: %s", d.Id())
 	}
 
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 
 	projectAccessTokenID, err := strconv.Atoi(PATstring)
 	if err != nil {
@@ -195,7 +195,7 @@ func resourceGitlabProjectAccessTokenDelete(ctx context.Context, d *schema.Resou
 		return diag.Errorf("Error parsing ID: %s", d.Id())
 	}
 
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 
 	projectAccessTokenID, err := strconv.Atoi(PATstring)
 	if err != nil {
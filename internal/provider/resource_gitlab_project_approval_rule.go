@@ -2,9 +2,12 @@ package provider
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"log"
+	"net/http"
+	"net/url"
 	"strconv"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -16,6 +19,35 @@ import (
 // https://docs.gitlab.com/ee/api/merge_request_approvals.html#create-project-level-rule
 var errApprovalRuleNotFound = errors.New("approval rule not found")
 
+var validApprovalRuleReportTypes = []string{"vulnerability", "license_scanning", "code_coverage"}
+var validApprovalRuleScanners = []string{"sast", "secret_detection", "dependency_scanning", "container_scanning", "dast", "coverage_fuzzing", "api_fuzzing", "cluster_image_scanning"}
+var validApprovalRuleSeverityLevels = []string{"unknown", "info", "low", "medium", "high", "critical"}
+var validApprovalRuleVulnerabilityStates = []string{"newly_detected", "detected", "confirmed", "resolved", "dismissed"}
+
+// approvalRuleVulnerabilityAttributes are the attributes only supported by rules with the `vulnerability` report type.
+var approvalRuleVulnerabilityAttributes = []string{"scanners", "severity_levels", "vulnerabilities_allowed", "vulnerability_states"}
+
+// projectApprovalRule is a project-level approval rule including the attributes of report approver rules,
+// which aren't supported by the GitLab client yet.
+type projectApprovalRule struct {
+	gitlab.ProjectApprovalRule
+	ReportType             string   `json:"report_type"`
+	Scanners               []string `json:"scanners"`
+	SeverityLevels         []string `json:"severity_levels"`
+	VulnerabilitiesAllowed int      `json:"vulnerabilities_allowed"`
+	VulnerabilityStates    []string `json:"vulnerability_states"`
+}
+
+// projectApprovalRuleOptions are the options to create or update a project-level approval rule.
+type projectApprovalRuleOptions struct {
+	gitlab.CreateProjectLevelRuleOptions
+	ReportType             *string   `json:"report_type,omitempty"`
+	Scanners               *[]string `json:"scanners,omitempty"`
+	SeverityLevels         *[]string `json:"severity_levels,omitempty"`
+	VulnerabilitiesAllowed *int      `json:"vulnerabilities_allowed,omitempty"`
+	VulnerabilityStates    *[]string `json:"vulnerability_states,omitempty"`
+}
+
 var _ = registerResource("gitlab_project_approval_rule", func() *schema.Resource {
 	var validRuleTypeValues = []string{
 		"regular",
@@ -35,6 +67,7 @@ var _ = registerResource("gitlab_project_approval_rule", func() *schema.Resource
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		CustomizeDiff: customizeDiffApprovalRuleReportType,
 		Schema: map[string]*schema.Schema{
 			"project": {
 				Description: "The name or id of the project to add the approval rules.",
@@ -53,7 +86,7 @@ var _ = registerResource("gitlab_project_approval_rule", func() *schema.Resource
 				Required:    true,
 			},
 			"rule_type": {
-				Description:      fmt.Sprintf("String, defaults to 'regular'. The type of rule. `any_approver` is a pre-configured default rule with `approvals_required` at `0`. Valid values are %s.", renderValueListForDocs(validRuleTypeValues)),
+				Description:      fmt.Sprintf("String, defaults to 'regular'. The type of rule. `any_approver` is a pre-configured default rule with `approvals_required` at `0`. Rules with a `report_type` are of type `report_approver`. Valid values are %s.", renderValueListForDocs(validRuleTypeValues)),
 				Type:             schema.TypeString,
 				ForceNew:         true,
 				Optional:         true,
@@ -81,20 +114,59 @@ var _ = registerResource("gitlab_project_approval_rule", func() *schema.Resource
 				Elem:        &schema.Schema{Type: schema.TypeInt},
 				Set:         schema.HashInt,
 			},
+			"report_type": {
+				Description:      fmt.Sprintf("The report type required for the rule, which makes it a `report_approver` rule. Valid values are %s. Requires GitLab 15.0 or newer.", renderValueListForDocs(validApprovalRuleReportTypes)),
+				Type:             schema.TypeString,
+				ForceNew:         true,
+				Optional:         true,
+				ConflictsWith:    []string{"rule_type"},
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice(validApprovalRuleReportTypes, false)),
+			},
+			"scanners": {
+				Description: fmt.Sprintf("The security scanners whose findings require an approval. Only for the `vulnerability` report type. Valid values are %s.", renderValueListForDocs(validApprovalRuleScanners)),
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringInSlice(validApprovalRuleScanners, false),
+				},
+			},
+			"severity_levels": {
+				Description: fmt.Sprintf("The severity levels of findings which require an approval. Only for the `vulnerability` report type. Valid values are %s.", renderValueListForDocs(validApprovalRuleSeverityLevels)),
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringInSlice(validApprovalRuleSeverityLevels, false),
+				},
+			},
+			"vulnerabilities_allowed": {
+				Description:  "The number of vulnerabilities allowed before an approval is required. Only for the `vulnerability` report type.",
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"vulnerability_states": {
+				Description: fmt.Sprintf("The states of vulnerabilities which require an approval. Only for the `vulnerability` report type. Valid values are %s.", renderValueListForDocs(validApprovalRuleVulnerabilityStates)),
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringInSlice(validApprovalRuleVulnerabilityStates, false),
+				},
+			},
 		},
 	}
 })
 
 func resourceGitlabProjectApprovalRuleCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	options := gitlab.CreateProjectLevelRuleOptions{
-		Name:               gitlab.String(d.Get("name").(string)),
-		ApprovalsRequired:  gitlab.Int(d.Get("approvals_required").(int)),
-		UserIDs:            expandApproverIds(d.Get("user_ids")),
-		GroupIDs:           expandApproverIds(d.Get("group_ids")),
-		ProtectedBranchIDs: expandProtectedBranchIDs(d.Get("protected_branch_ids")),
-	}
+	options := expandProjectApprovalRuleOptions(d)
 
-	if v, ok := d.GetOk("rule_type"); ok {
+	if v, ok := d.GetOk("rule_type"); ok && options.ReportType == nil {
 		options.RuleType = gitlab.String(v.(string))
 	}
 
@@ -102,14 +174,30 @@ func resourceGitlabProjectApprovalRuleCreate(ctx context.Context, d *schema.Reso
 
 	log.Printf("[DEBUG] Project %s create gitlab project-level rule %+v", project, options)
 
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 
-	rule, _, err := client.Projects.CreateProjectApprovalRule(project, &options, gitlab.WithContext(ctx))
-	if err != nil {
-		return diag.FromErr(err)
+	var ruleID int
+	if options.ReportType == nil {
+		rule, _, err := client.Projects.CreateProjectApprovalRule(project, &options.CreateProjectLevelRuleOptions, gitlab.WithContext(ctx))
+		if err != nil {
+			return meta.(*providerMeta).premiumDiagnostics(err, "failed to create approval rule of project %s", project)
+		}
+		ruleID = rule.ID
+	} else {
+		// The report approver attributes aren't supported by the GitLab client yet.
+		result, _, err := gitlabAPIRequest(ctx, client, http.MethodPost, fmt.Sprintf("projects/%s/approval_rules", url.PathEscape(project)), nil, options)
+		if err != nil {
+			return meta.(*providerMeta).premiumDiagnostics(err, "failed to create approval rule of project %s", project)
+		}
+
+		var rule projectApprovalRule
+		if err := json.Unmarshal(result, &rule); err != nil {
+			return diag.Errorf("failed to decode gitlab project-level rule of project %s: %v", project, err)
+		}
+		ruleID = rule.ID
 	}
 
-	ruleIDString := strconv.Itoa(rule.ID)
+	ruleIDString := strconv.Itoa(ruleID)
 
 	d.SetId(buildTwoPartID(&project, &ruleIDString))
 
@@ -125,7 +213,9 @@ func resourceGitlabProjectApprovalRuleRead(ctx context.Context, d *schema.Resour
 	}
 	d.Set("project", projectID)
 
-	rule, err := getApprovalRuleByID(ctx, meta.(*gitlab.Client), d.Id())
+	client := meta.(*providerMeta).client
+
+	rule, err := getApprovalRuleByID(ctx, client, d.Id())
 	if err != nil {
 		if errors.Is(err, errApprovalRuleNotFound) {
 			d.SetId("")
@@ -150,6 +240,30 @@ func resourceGitlabProjectApprovalRuleRead(ctx context.Context, d *schema.Resour
 		return diag.FromErr(err)
 	}
 
+	// The report approver attributes aren't supported by the GitLab client yet.
+	reportRule := &projectApprovalRule{}
+	if rule.RuleType == "report_approver" {
+		reportRule, err = getProjectApprovalRuleReportAttributes(ctx, client, projectID, rule.ID)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	d.Set("report_type", reportRule.ReportType)
+	d.Set("vulnerabilities_allowed", reportRule.VulnerabilitiesAllowed)
+
+	if err := d.Set("scanners", reportRule.Scanners); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("severity_levels", reportRule.SeverityLevels); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("vulnerability_states", reportRule.VulnerabilityStates); err != nil {
+		return diag.FromErr(err)
+	}
+
 	return nil
 }
 
@@ -164,19 +278,24 @@ func resourceGitlabProjectApprovalRuleUpdate(ctx context.Context, d *schema.Reso
 		return diag.FromErr(err)
 	}
 
-	options := gitlab.UpdateProjectLevelRuleOptions{
-		Name:               gitlab.String(d.Get("name").(string)),
-		ApprovalsRequired:  gitlab.Int(d.Get("approvals_required").(int)),
-		UserIDs:            expandApproverIds(d.Get("user_ids")),
-		GroupIDs:           expandApproverIds(d.Get("group_ids")),
-		ProtectedBranchIDs: expandProtectedBranchIDs(d.Get("protected_branch_ids")),
-	}
+	options := expandProjectApprovalRuleOptions(d)
 
 	log.Printf("[DEBUG] Project %s update gitlab project-level approval rule %s", projectID, *options.Name)
 
-	client := meta.(*gitlab.Client)
-
-	_, _, err = client.Projects.UpdateProjectApprovalRule(projectID, ruleIDInt, &options, gitlab.WithContext(ctx))
+	client := meta.(*providerMeta).client
+
+	if options.ReportType == nil {
+		_, _, err = client.Projects.UpdateProjectApprovalRule(projectID, ruleIDInt, &gitlab.UpdateProjectLevelRuleOptions{
+			Name:               options.Name,
+			ApprovalsRequired:  options.ApprovalsRequired,
+			UserIDs:            options.UserIDs,
+			GroupIDs:           options.GroupIDs,
+			ProtectedBranchIDs: options.ProtectedBranchIDs,
+		}, gitlab.WithContext(ctx))
+	} else {
+		// The report approver attributes aren't supported by the GitLab client yet.
+		_, _, err = gitlabAPIRequest(ctx, client, http.MethodPut, fmt.Sprintf("projects/%s/approval_rules/%d", url.PathEscape(projectID), ruleIDInt), nil, options)
+	}
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -197,7 +316,7 @@ func resourceGitlabProjectApprovalRuleDelete(ctx context.Context, d *schema.Reso
 
 	log.Printf("[DEBUG] Project %s delete gitlab project-level approval rule %d", project, ruleIDInt)
 
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 
 	_, err = client.Projects.DeleteProjectApprovalRule(project, ruleIDInt, gitlab.WithContext(ctx))
 	if err != nil {
@@ -236,6 +355,101 @@ func getApprovalRuleByID(ctx context.Context, client *gitlab.Client, id string)
 	return nil, errApprovalRuleNotFound
 }
 
+// getProjectApprovalRuleReportAttributes returns a project-level rule including its report approver attributes.
+func getProjectApprovalRuleReportAttributes(ctx context.Context, client *gitlab.Client, projectID string, ruleID int) (*projectApprovalRule, error) {
+	result, _, err := gitlabAPIRequest(ctx, client, http.MethodGet, fmt.Sprintf("projects/%s/approval_rules/%d", url.PathEscape(projectID), ruleID), nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var rule projectApprovalRule
+	if err := json.Unmarshal(result, &rule); err != nil {
+		return nil, fmt.Errorf("failed to decode approval rule %d of project %s: %w", ruleID, projectID, err)
+	}
+	return &rule, nil
+}
+
+// getProjectApprovalRules returns all project-level approval rules of a project, including their report approver attributes.
+func getProjectApprovalRules(ctx context.Context, client *gitlab.Client, projectID string) ([]*projectApprovalRule, error) {
+	result, err := gitlabAPIGetAllPages(ctx, client, fmt.Sprintf("projects/%s/approval_rules", url.PathEscape(projectID)), nil, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	var rules []*projectApprovalRule
+	if err := json.Unmarshal(result, &rules); err != nil {
+		return nil, fmt.Errorf("failed to decode approval rules of project %s: %w", projectID, err)
+	}
+	return rules, nil
+}
+
+// expandProjectApprovalRuleOptions returns the options to create or update a project-level rule from its configuration.
+func expandProjectApprovalRuleOptions(d *schema.ResourceData) *projectApprovalRuleOptions {
+	options := &projectApprovalRuleOptions{
+		CreateProjectLevelRuleOptions: gitlab.CreateProjectLevelRuleOptions{
+			Name:               gitlab.String(d.Get("name").(string)),
+			ApprovalsRequired:  gitlab.Int(d.Get("approvals_required").(int)),
+			UserIDs:            expandApproverIds(d.Get("user_ids")),
+			GroupIDs:           expandApproverIds(d.Get("group_ids")),
+			ProtectedBranchIDs: expandProtectedBranchIDs(d.Get("protected_branch_ids")),
+		},
+	}
+
+	reportType := d.Get("report_type").(string)
+	if reportType == "" {
+		return options
+	}
+
+	options.RuleType = gitlab.String("report_approver")
+	options.ReportType = gitlab.String(reportType)
+
+	if reportType == "vulnerability" {
+		if v, ok := d.GetOk("scanners"); ok {
+			options.Scanners = stringSetToStringSlice(v.(*schema.Set))
+		}
+		if v, ok := d.GetOk("severity_levels"); ok {
+			options.SeverityLevels = stringSetToStringSlice(v.(*schema.Set))
+		}
+		if v, ok := d.GetOk("vulnerability_states"); ok {
+			options.VulnerabilityStates = stringSetToStringSlice(v.(*schema.Set))
+		}
+		options.VulnerabilitiesAllowed = gitlab.Int(d.Get("vulnerabilities_allowed").(int))
+	}
+
+	return options
+}
+
+// customizeDiffApprovalRuleReportType validates the report approver attributes of a rule,
+// including that the GitLab instance supports them.
+func customizeDiffApprovalRuleReportType(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	config := d.GetRawConfig()
+	if config.IsNull() || !config.IsKnown() || !d.NewValueKnown("report_type") {
+		return nil
+	}
+
+	reportType := d.Get("report_type").(string)
+	if reportType != "vulnerability" {
+		for _, attr := range approvalRuleVulnerabilityAttributes {
+			if !config.GetAttr(attr).IsNull() {
+				return fmt.Errorf("`%s` requires `report_type = \"vulnerability\"`", attr)
+			}
+		}
+	}
+
+	if reportType == "" || !d.HasChange("report_type") {
+		return nil
+	}
+
+	supported, err := meta.(*providerMeta).supportsFeature("approval_rule_report_type")
+	if err != nil {
+		return err
+	}
+	if !supported {
+		return fmt.Errorf("`report_type` requires GitLab %s or newer", gitlabFeatureMinVersions["approval_rule_report_type"])
+	}
+	return nil
+}
+
 // flattenApprovalRuleUserIDs flattens a list of approval user ids into a list
 // of ints for storage in state.
 func flattenApprovalRuleUserIDs(users []*gitlab.BasicUser) []int {
@@ -2,6 +2,7 @@ package provider
 
 import (
 	"fmt"
+	"regexp"
 	"strconv"
 	"testing"
 
@@ -141,6 +142,62 @@ func TestAccGitLabProjectApprovalRule_AnyApprover(t *testing.T) {
 	})
 }
 
+func TestAccGitLabProjectApprovalRule_ReportType(t *testing.T) {
+	testAccCheck(t)
+	testAccCheckEE(t)
+
+	project := testAccCreateProject(t)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: providerFactories,
+		CheckDestroy:      testAccCheckGitlabProjectApprovalRuleDestroy(project.ID),
+		Steps: []resource.TestStep{
+			// Vulnerability attributes require the vulnerability report type
+			{
+				SkipFunc: isGitLabVersionLessThan(testGitlabClient, "15.0"),
+				Config: fmt.Sprintf(`
+resource "gitlab_project_approval_rule" "report" {
+  project            = %d
+  name               = "Coverage-Check"
+  approvals_required = 1
+  report_type        = "code_coverage"
+  scanners           = ["sast"]
+}`, project.ID),
+				ExpectError: regexp.MustCompile("`scanners` requires `report_type = \"vulnerability\"`"),
+			},
+			// Create vulnerability rule
+			{
+				SkipFunc: isGitLabVersionLessThan(testGitlabClient, "15.0"),
+				Config:   testAccGitlabProjectApprovalRuleConfig_Vulnerability(project.ID, 0, `["sast", "dependency_scanning"]`, `["high", "critical"]`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("gitlab_project_approval_rule.report", "rule_type", "report_approver"),
+					resource.TestCheckResourceAttr("gitlab_project_approval_rule.report", "report_type", "vulnerability"),
+					resource.TestCheckResourceAttr("gitlab_project_approval_rule.report", "scanners.#", "2"),
+					resource.TestCheckResourceAttr("gitlab_project_approval_rule.report", "severity_levels.#", "2"),
+					resource.TestCheckResourceAttr("gitlab_project_approval_rule.report", "vulnerabilities_allowed", "0"),
+				),
+			},
+			// Update vulnerability rule
+			{
+				SkipFunc: isGitLabVersionLessThan(testGitlabClient, "15.0"),
+				Config:   testAccGitlabProjectApprovalRuleConfig_Vulnerability(project.ID, 2, `["container_scanning"]`, `["critical"]`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("gitlab_project_approval_rule.report", "scanners.#", "1"),
+					resource.TestCheckResourceAttr("gitlab_project_approval_rule.report", "severity_levels.#", "1"),
+					resource.TestCheckResourceAttr("gitlab_project_approval_rule.report", "vulnerabilities_allowed", "2"),
+				),
+			},
+			// Verify import
+			{
+				SkipFunc:          isGitLabVersionLessThan(testGitlabClient, "15.0"),
+				ResourceName:      "gitlab_project_approval_rule.report",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
 type testAccGitlabProjectApprovalRuleExpectedAttributes_Basic struct {
 	Name                string
 	ApprovalsRequired   int
@@ -214,6 +271,20 @@ resource "gitlab_project_approval_rule" "bar" {
 }`, project, approvals, rule_type)
 }
 
+func testAccGitlabProjectApprovalRuleConfig_Vulnerability(project, vulnerabilitiesAllowed int, scanners, severityLevels string) string {
+	return fmt.Sprintf(`
+resource "gitlab_project_approval_rule" "report" {
+  project                 = %d
+  name                    = "Vulnerability-Check"
+  approvals_required      = 1
+  report_type             = "vulnerability"
+  vulnerabilities_allowed = %d
+  scanners                = %s
+  severity_levels         = %s
+  vulnerability_states    = ["newly_detected"]
+}`, project, vulnerabilitiesAllowed, scanners, severityLevels)
+}
+
 func testAccCheckGitlabProjectApprovalRuleExists(n string, projectApprovalRule *gitlab.ProjectApprovalRule) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[n]
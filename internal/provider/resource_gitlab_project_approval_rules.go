@@ -0,0 +1,374 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	gitlab "github.com/xanzy/go-gitlab"
+)
+
+var _ = registerResource("gitlab_project_approval_rules", func() *schema.Resource {
+	var validRuleTypeValues = []string{
+		"regular",
+		"any_approver",
+	}
+	return &schema.Resource{
+		Description: `The ` + "`" + `gitlab_project_approval_rules` + "`" + ` resource allows to manage all project-level approval rules of a project.
+It is authoritative: rules which are not configured, e.g. added in the UI, show up in the plan and are deleted on apply.
+Rules are matched by their name.
+
+-> This resource must not be used together with the ` + "`gitlab_project_approval_rule`" + ` resource for the same project.
+Destroying this resource deletes all approval rules of the project.
+
+-> This resource requires a GitLab Enterprise instance.
+
+**Upstream API**: [GitLab REST API docs](https://docs.gitlab.com/ee/api/merge_request_approvals.html#project-level-mr-approvals)`,
+
+		CreateContext: resourceGitlabProjectApprovalRulesCreate,
+		ReadContext:   resourceGitlabProjectApprovalRulesRead,
+		UpdateContext: resourceGitlabProjectApprovalRulesUpdate,
+		DeleteContext: resourceGitlabProjectApprovalRulesDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		CustomizeDiff: customizeDiffProjectApprovalRules,
+		Schema: map[string]*schema.Schema{
+			"project": {
+				Description: "The name or id of the project to manage the approval rules of.",
+				Type:        schema.TypeString,
+				ForceNew:    true,
+				Required:    true,
+			},
+			"rule": {
+				Description: "The approval rules of the project. Rules which are not listed are deleted.",
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Description: "The id of the approval rule.",
+							Type:        schema.TypeInt,
+							Computed:    true,
+						},
+						"name": {
+							Description: "The name of the approval rule, which must be unique in the project.",
+							Type:        schema.TypeString,
+							Required:    true,
+						},
+						"approvals_required": {
+							Description: "The number of approvals required for this rule.",
+							Type:        schema.TypeInt,
+							Required:    true,
+						},
+						"rule_type": {
+							Description:      fmt.Sprintf("String, defaults to 'regular'. The type of rule. Rules with a `report_type` are of type `report_approver`. Valid values are %s.", renderValueListForDocs(validRuleTypeValues)),
+							Type:             schema.TypeString,
+							Optional:         true,
+							ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice(validRuleTypeValues, false)),
+							DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+								return projectApprovalRulesRuleType(old) == projectApprovalRulesRuleType(new)
+							},
+						},
+						"report_type": {
+							Description:      fmt.Sprintf("The report type required for the rule, which makes it a `report_approver` rule. Changing it recreates the rule. Valid values are %s. Requires GitLab 15.0 or newer.", renderValueListForDocs(validApprovalRuleReportTypes)),
+							Type:             schema.TypeString,
+							Optional:         true,
+							ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice(validApprovalRuleReportTypes, false)),
+						},
+						"user_ids": {
+							Description: "A list of specific User IDs to add to the list of approvers.",
+							Type:        schema.TypeSet,
+							Optional:    true,
+							Elem:        &schema.Schema{Type: schema.TypeInt},
+							Set:         schema.HashInt,
+						},
+						"group_ids": {
+							Description: "A list of group IDs whose members can approve of the merge request.",
+							Type:        schema.TypeSet,
+							Optional:    true,
+							Elem:        &schema.Schema{Type: schema.TypeInt},
+							Set:         schema.HashInt,
+						},
+						"protected_branch_ids": {
+							Description: "A list of protected branch IDs (not branch names) for which the rule applies.",
+							Type:        schema.TypeSet,
+							Optional:    true,
+							Elem:        &schema.Schema{Type: schema.TypeInt},
+							Set:         schema.HashInt,
+						},
+					},
+				},
+			},
+		},
+	}
+})
+
+func resourceGitlabProjectApprovalRulesCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	project := d.Get("project").(string)
+
+	log.Printf("[DEBUG] create gitlab project-level rules of project %s", project)
+
+	if err := resourceGitlabProjectApprovalRulesApply(ctx, meta.(*providerMeta).client, project, d.Get("rule").([]interface{})); err != nil {
+		return meta.(*providerMeta).premiumDiagnostics(err, "failed to apply approval rules of project %s", project)
+	}
+
+	d.SetId(project)
+
+	return resourceGitlabProjectApprovalRulesRead(ctx, d, meta)
+}
+
+func resourceGitlabProjectApprovalRulesRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Printf("[DEBUG] read gitlab project-level rules of project %s", d.Id())
+
+	rules, err := getProjectApprovalRules(ctx, meta.(*providerMeta).client, d.Id())
+	if err != nil {
+		if is404(err) {
+			log.Printf("[DEBUG] gitlab project %s not found, removing approval rules from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+
+	d.Set("project", d.Id())
+
+	if err := d.Set("rule", flattenProjectApprovalRules(rules, d.Get("rule").([]interface{}))); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceGitlabProjectApprovalRulesUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Printf("[DEBUG] update gitlab project-level rules of project %s", d.Id())
+
+	if err := resourceGitlabProjectApprovalRulesApply(ctx, meta.(*providerMeta).client, d.Id(), d.Get("rule").([]interface{})); err != nil {
+		return diag.Errorf("failed to apply approval rules of project %s: %v", d.Id(), err)
+	}
+
+	return resourceGitlabProjectApprovalRulesRead(ctx, d, meta)
+}
+
+func resourceGitlabProjectApprovalRulesDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+
+	// The resource manages all rules of the project, including those created since the last refresh.
+	rules, err := getProjectApprovalRules(ctx, client, d.Id())
+	if err != nil {
+		if is404(err) {
+			return nil
+		}
+		return diag.Errorf("failed to list approval rules of project %s: %v", d.Id(), err)
+	}
+
+	for _, rule := range rules {
+		log.Printf("[DEBUG] Project %s delete gitlab project-level approval rule %d", d.Id(), rule.ID)
+
+		_, err := client.Projects.DeleteProjectApprovalRule(d.Id(), rule.ID, gitlab.WithContext(ctx))
+		if err != nil && !is404(err) {
+			return diag.FromErr(err)
+		}
+	}
+
+	return nil
+}
+
+// resourceGitlabProjectApprovalRulesApply makes the approval rules of a project match the configured rules.
+// The configured rules are updated or created first and unconfigured rules are deleted last,
+// so that the project is never left without the configured rules in between.
+func resourceGitlabProjectApprovalRulesApply(ctx context.Context, client *gitlab.Client, project string, configured []interface{}) error {
+	existing, err := getProjectApprovalRules(ctx, client, project)
+	if err != nil {
+		return err
+	}
+
+	configuredNames := make(map[string]bool)
+	for _, raw := range configured {
+		configuredNames[raw.(map[string]interface{})["name"].(string)] = true
+	}
+
+	existingByName := make(map[string]*projectApprovalRule)
+	var obsolete []*projectApprovalRule
+	for _, rule := range existing {
+		if configuredNames[rule.Name] {
+			existingByName[rule.Name] = rule
+		} else {
+			obsolete = append(obsolete, rule)
+		}
+	}
+
+	for _, raw := range configured {
+		rule := raw.(map[string]interface{})
+		options := expandProjectApprovalRulesRule(rule)
+
+		current, ok := existingByName[*options.Name]
+		if ok && projectApprovalRulesRuleRequiresRecreate(current, rule) {
+			// The names of rules are unique, thus the rule is deleted before it's created again.
+			log.Printf("[DEBUG] Project %s delete gitlab project-level approval rule %q to recreate it", project, current.Name)
+			if _, err := client.Projects.DeleteProjectApprovalRule(project, current.ID, gitlab.WithContext(ctx)); err != nil && !is404(err) {
+				return fmt.Errorf("failed to delete rule %q to recreate it: %w", current.Name, err)
+			}
+			ok = false
+		}
+
+		if ok {
+			log.Printf("[DEBUG] Project %s update gitlab project-level approval rule %q", project, current.Name)
+			if _, _, err := gitlabAPIRequest(ctx, client, http.MethodPut, fmt.Sprintf("projects/%s/approval_rules/%d", url.PathEscape(project), current.ID), nil, options); err != nil {
+				return fmt.Errorf("failed to update rule %q: %w", current.Name, err)
+			}
+			continue
+		}
+
+		log.Printf("[DEBUG] Project %s create gitlab project-level approval rule %+v", project, options)
+		if _, _, err := gitlabAPIRequest(ctx, client, http.MethodPost, fmt.Sprintf("projects/%s/approval_rules", url.PathEscape(project)), nil, options); err != nil {
+			return fmt.Errorf("failed to create rule %q: %w", *options.Name, err)
+		}
+	}
+
+	for _, rule := range obsolete {
+		log.Printf("[DEBUG] Project %s delete gitlab project-level approval rule %q", project, rule.Name)
+		if _, err := client.Projects.DeleteProjectApprovalRule(project, rule.ID, gitlab.WithContext(ctx)); err != nil && !is404(err) {
+			return fmt.Errorf("failed to delete rule %q: %w", rule.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// projectApprovalRulesRuleRequiresRecreate returns true if the report type of an existing rule differs from its configuration,
+// which can't be changed by updating the rule. All other attributes are updated in place.
+func projectApprovalRulesRuleRequiresRecreate(current *projectApprovalRule, rule map[string]interface{}) bool {
+	return current.ReportType != rule["report_type"].(string)
+}
+
+// projectApprovalRulesRuleType returns the type of rule for the `rule_type` attribute, which defaults to `regular`.
+// Rules with a `report_type` are stored without `rule_type`, see flattenProjectApprovalRules.
+func projectApprovalRulesRuleType(ruleType string) string {
+	if ruleType == "" {
+		return "regular"
+	}
+	return ruleType
+}
+
+// expandProjectApprovalRulesRule returns the options to create or update a rule of the `rule` attribute.
+func expandProjectApprovalRulesRule(rule map[string]interface{}) *projectApprovalRuleOptions {
+	options := &projectApprovalRuleOptions{
+		CreateProjectLevelRuleOptions: gitlab.CreateProjectLevelRuleOptions{
+			Name:               gitlab.String(rule["name"].(string)),
+			ApprovalsRequired:  gitlab.Int(rule["approvals_required"].(int)),
+			UserIDs:            expandApproverIds(rule["user_ids"]),
+			GroupIDs:           expandApproverIds(rule["group_ids"]),
+			ProtectedBranchIDs: expandProtectedBranchIDs(rule["protected_branch_ids"]),
+		},
+	}
+
+	if reportType := rule["report_type"].(string); reportType != "" {
+		options.RuleType = gitlab.String("report_approver")
+		options.ReportType = gitlab.String(reportType)
+	} else {
+		options.RuleType = gitlab.String(projectApprovalRulesRuleType(rule["rule_type"].(string)))
+	}
+
+	return options
+}
+
+// flattenProjectApprovalRules flattens the approval rules of a project in the order of the prior rules,
+// so that the plan only shows actual changes. Rules which aren't part of the prior rules are appended.
+// The `rule_type` is only stored for rules without `report_type`, because it is implied by the report type.
+func flattenProjectApprovalRules(rules []*projectApprovalRule, prior []interface{}) []map[string]interface{} {
+	order := make(map[string]int)
+	for i, raw := range prior {
+		if rule, ok := raw.(map[string]interface{}); ok {
+			order[rule["name"].(string)] = i
+		}
+	}
+
+	sorted := make([]*projectApprovalRule, len(rules))
+	copy(sorted, rules)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		iOrder, iOk := order[sorted[i].Name]
+		jOrder, jOk := order[sorted[j].Name]
+		switch {
+		case iOk && jOk:
+			return iOrder < jOrder
+		case iOk != jOk:
+			return iOk
+		default:
+			return sorted[i].ID < sorted[j].ID
+		}
+	})
+
+	var values []map[string]interface{}
+	for _, rule := range sorted {
+		ruleType := rule.RuleType
+		if rule.ReportType != "" {
+			ruleType = ""
+		}
+
+		values = append(values, map[string]interface{}{
+			"id":                   rule.ID,
+			"name":                 rule.Name,
+			"approvals_required":   rule.ApprovalsRequired,
+			"rule_type":            ruleType,
+			"report_type":          rule.ReportType,
+			"user_ids":             flattenApprovalRuleUserIDs(rule.Users),
+			"group_ids":            flattenApprovalRuleGroupIDs(rule.Groups),
+			"protected_branch_ids": flattenProtectedBranchIDs(rule.ProtectedBranches),
+		})
+	}
+	return values
+}
+
+// customizeDiffProjectApprovalRules validates that the names of the rules are unique
+// and that the GitLab instance supports report approver rules if they are configured.
+func customizeDiffProjectApprovalRules(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	config := d.GetRawConfig()
+	if config.IsNull() || !config.IsKnown() {
+		return nil
+	}
+	configRules := config.GetAttr("rule")
+	if configRules.IsNull() || !configRules.IsKnown() {
+		return nil
+	}
+
+	names := make(map[string]bool)
+	hasReportType := false
+
+	for i, rule := range configRules.AsValueSlice() {
+		name := rule.GetAttr("name")
+		if !name.IsKnown() || name.IsNull() {
+			continue
+		}
+		if names[name.AsString()] {
+			return fmt.Errorf("the name of each rule must be unique, but %q is used more than once", name.AsString())
+		}
+		names[name.AsString()] = true
+
+		if reportType := rule.GetAttr("report_type"); !reportType.IsNull() {
+			if !rule.GetAttr("rule_type").IsNull() {
+				return fmt.Errorf("rule %d: `rule_type` conflicts with `report_type`", i)
+			}
+			hasReportType = true
+		}
+	}
+
+	if !hasReportType || !d.HasChange("rule") {
+		return nil
+	}
+
+	supported, err := meta.(*providerMeta).supportsFeature("approval_rule_report_type")
+	if err != nil {
+		return err
+	}
+	if !supported {
+		return fmt.Errorf("`report_type` requires GitLab %s or newer", gitlabFeatureMinVersions["approval_rule_report_type"])
+	}
+	return nil
+}
@@ -0,0 +1,229 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	gitlab "github.com/xanzy/go-gitlab"
+)
+
+func TestAccGitLabProjectApprovalRules_Basic(t *testing.T) {
+	testAccCheck(t)
+	testAccCheckEE(t)
+
+	project := testAccCreateProject(t)
+	users := testAccCreateUsers(t, 2)
+	testAccAddProjectMembers(t, project.ID, users) // Users must belong to the project for rules to work.
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: providerFactories,
+		CheckDestroy:      testAccCheckGitlabProjectApprovalRuleDestroy(project.ID),
+		Steps: []resource.TestStep{
+			// Create rules
+			{
+				Config: testAccGitlabProjectApprovalRulesConfig(project.ID, `
+  rule {
+    name               = "security"
+    approvals_required = 1
+    user_ids           = [`+strconv.Itoa(users[0].ID)+`]
+  }
+
+  rule {
+    name               = "qa"
+    approvals_required = 2
+  }
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("gitlab_project_approval_rules.foo", "rule.#", "2"),
+					resource.TestCheckResourceAttr("gitlab_project_approval_rules.foo", "rule.0.name", "security"),
+					resource.TestCheckResourceAttr("gitlab_project_approval_rules.foo", "rule.1.name", "qa"),
+					testAccCheckGitlabProjectApprovalRulesNames(project.ID, "security", "qa"),
+				),
+			},
+			// Rules added outside of Terraform show up in the plan
+			{
+				PreConfig: func() {
+					_, _, err := testGitlabClient.Projects.CreateProjectApprovalRule(project.ID, &gitlab.CreateProjectLevelRuleOptions{
+						Name:              gitlab.String("manual"),
+						ApprovalsRequired: gitlab.Int(1),
+					})
+					if err != nil {
+						t.Fatalf("failed to create unmanaged approval rule: %v", err)
+					}
+				},
+				Config: testAccGitlabProjectApprovalRulesConfig(project.ID, `
+  rule {
+    name               = "security"
+    approvals_required = 1
+    user_ids           = [`+strconv.Itoa(users[0].ID)+`]
+  }
+
+  rule {
+    name               = "qa"
+    approvals_required = 2
+  }
+`),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			// Update rules, which deletes unmanaged and removed rules
+			{
+				Config: testAccGitlabProjectApprovalRulesConfig(project.ID, `
+  rule {
+    name               = "security"
+    approvals_required = 2
+    user_ids           = [`+strconv.Itoa(users[1].ID)+`]
+  }
+
+  rule {
+    name               = "any"
+    rule_type          = "any_approver"
+    approvals_required = 1
+  }
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("gitlab_project_approval_rules.foo", "rule.#", "2"),
+					resource.TestCheckResourceAttr("gitlab_project_approval_rules.foo", "rule.0.approvals_required", "2"),
+					resource.TestCheckResourceAttr("gitlab_project_approval_rules.foo", "rule.1.rule_type", "any_approver"),
+					testAccCheckGitlabProjectApprovalRulesNames(project.ID, "security", "any"),
+				),
+			},
+			// Verify import
+			{
+				ResourceName:      "gitlab_project_approval_rules.foo",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Change the type of a rule, which updates it in place
+			{
+				Config: testAccGitlabProjectApprovalRulesConfig(project.ID, `
+  rule {
+    name               = "security"
+    approvals_required = 2
+    user_ids           = [`+strconv.Itoa(users[1].ID)+`]
+  }
+
+  rule {
+    name               = "any"
+    approvals_required = 1
+  }
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("gitlab_project_approval_rules.foo", "rule.1.rule_type", "regular"),
+					testAccCheckGitlabProjectApprovalRulesNames(project.ID, "security", "any"),
+				),
+			},
+			// Rules added since the last refresh are deleted on destroy as well, see CheckDestroy
+			{
+				PreConfig: func() {
+					_, _, err := testGitlabClient.Projects.CreateProjectApprovalRule(project.ID, &gitlab.CreateProjectLevelRuleOptions{
+						Name:              gitlab.String("manual"),
+						ApprovalsRequired: gitlab.Int(1),
+					})
+					if err != nil {
+						t.Fatalf("failed to create unmanaged approval rule: %v", err)
+					}
+				},
+				Config:  testAccGitlabProjectApprovalRulesConfig(project.ID, ""),
+				Destroy: true,
+			},
+		},
+	})
+}
+
+func TestFlattenProjectApprovalRules(t *testing.T) {
+	rules := []*projectApprovalRule{
+		{ProjectApprovalRule: gitlab.ProjectApprovalRule{ID: 1, Name: "manual", RuleType: "regular"}},
+		{ProjectApprovalRule: gitlab.ProjectApprovalRule{ID: 2, Name: "b", RuleType: "regular"}},
+		{ProjectApprovalRule: gitlab.ProjectApprovalRule{ID: 3, Name: "a", RuleType: "report_approver"}, ReportType: "code_coverage"},
+	}
+	prior := []interface{}{
+		map[string]interface{}{"name": "a"},
+		map[string]interface{}{"name": "b"},
+	}
+
+	flattened := flattenProjectApprovalRules(rules, prior)
+
+	var names []string
+	for _, rule := range flattened {
+		names = append(names, rule["name"].(string))
+	}
+	if fmt.Sprint(names) != "[a b manual]" {
+		t.Fatalf("got rules in order %v, expected [a b manual]", names)
+	}
+	if ruleType := flattened[0]["rule_type"]; ruleType != "" {
+		t.Fatalf("got rule_type %q for report approver rule, expected none", ruleType)
+	}
+	if ruleType := flattened[1]["rule_type"]; ruleType != "regular" {
+		t.Fatalf("got rule_type %q, expected regular", ruleType)
+	}
+}
+
+func TestProjectApprovalRulesRuleRequiresRecreate(t *testing.T) {
+	cases := []struct {
+		Current  projectApprovalRule
+		Rule     map[string]interface{}
+		Recreate bool
+	}{
+		{
+			Current:  projectApprovalRule{ProjectApprovalRule: gitlab.ProjectApprovalRule{RuleType: "regular"}},
+			Rule:     map[string]interface{}{"rule_type": "", "report_type": ""},
+			Recreate: false,
+		},
+		{
+			Current:  projectApprovalRule{ProjectApprovalRule: gitlab.ProjectApprovalRule{RuleType: "regular"}},
+			Rule:     map[string]interface{}{"rule_type": "any_approver", "report_type": ""},
+			Recreate: false,
+		},
+		{
+			Current:  projectApprovalRule{ProjectApprovalRule: gitlab.ProjectApprovalRule{RuleType: "regular"}},
+			Rule:     map[string]interface{}{"rule_type": "", "report_type": "code_coverage"},
+			Recreate: true,
+		},
+		{
+			Current:  projectApprovalRule{ProjectApprovalRule: gitlab.ProjectApprovalRule{RuleType: "report_approver"}, ReportType: "code_coverage"},
+			Rule:     map[string]interface{}{"rule_type": "", "report_type": "code_coverage"},
+			Recreate: false,
+		},
+		{
+			Current:  projectApprovalRule{ProjectApprovalRule: gitlab.ProjectApprovalRule{RuleType: "report_approver"}, ReportType: "code_coverage"},
+			Rule:     map[string]interface{}{"rule_type": "", "report_type": "license_scanning"},
+			Recreate: true,
+		},
+	}
+
+	for _, tc := range cases {
+		if got := projectApprovalRulesRuleRequiresRecreate(&tc.Current, tc.Rule); got != tc.Recreate {
+			t.Errorf("rule %v with current rule type %q and report type %q: got %v expected %v", tc.Rule, tc.Current.RuleType, tc.Current.ReportType, got, tc.Recreate)
+		}
+	}
+}
+
+func testAccCheckGitlabProjectApprovalRulesNames(projectID int, names ...string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		return InterceptGomegaFailure(func() {
+			rules, err := getProjectApprovalRules(context.Background(), testGitlabClient, strconv.Itoa(projectID))
+			Expect(err).To(BeNil())
+
+			var got []string
+			for _, rule := range rules {
+				got = append(got, rule.Name)
+			}
+			Expect(got).To(ConsistOf(names), "rules")
+		})
+	}
+}
+
+func testAccGitlabProjectApprovalRulesConfig(project int, rules string) string {
+	return fmt.Sprintf(`
+resource "gitlab_project_approval_rules" "foo" {
+  project = %d
+%s
+}`, project, rules)
+}
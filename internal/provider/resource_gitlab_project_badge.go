@@ -56,7 +56,7 @@ var _ = registerResource("gitlab_project_badge", func() *schema.Resource {
 })
 
 func resourceGitlabProjectBadgeCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 	projectID := d.Get("project").(string)
 	options := &gitlab.AddProjectBadgeOptions{
 		LinkURL:  gitlab.String(d.Get("link_url").(string)),
@@ -78,7 +78,7 @@ func resourceGitlabProjectBadgeCreate(ctx context.Context, d *schema.ResourceDat
 }
 
 func resourceGitlabProjectBadgeRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 	ids := strings.Split(d.Id(), ":")
 	projectID := ids[0]
 	badgeID, err := strconv.Atoi(ids[1])
@@ -103,7 +103,7 @@ func resourceGitlabProjectBadgeRead(ctx context.Context, d *schema.ResourceData,
 }
 
 func resourceGitlabProjectBadgeUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 	ids := strings.Split(d.Id(), ":")
 	projectID := ids[0]
 	badgeID, err := strconv.Atoi(ids[1])
@@ -127,7 +127,7 @@ func resourceGitlabProjectBadgeUpdate(ctx context.Context, d *schema.ResourceDat
 }
 
 func resourceGitlabProjectBadgeDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 	ids := strings.Split(d.Id(), ":")
 	projectID := ids[0]
 	badgeID, err := strconv.Atoi(ids[1])
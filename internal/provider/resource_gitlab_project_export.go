@@ -0,0 +1,201 @@
+package provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	gitlab "github.com/xanzy/go-gitlab"
+)
+
+var _ = registerResource("gitlab_project_export", func() *schema.Resource {
+	return &schema.Resource{
+		Description: `The ` + "`gitlab_project_export`" + ` resource allows to export a project and download the export archive to a local file,
+e.g. to migrate the project to another GitLab instance with the ` + "`import_file`" + ` attribute of the ` + "`gitlab_project`" + ` resource.
+
+The export is scheduled on creation and the resource waits until it is finished. If the local archive is removed or modified,
+the project is exported again. Destroying the resource removes the local archive.
+
+**Upstream API**: [GitLab REST API docs](https://docs.gitlab.com/ee/api/project_import_export.html)`,
+
+		CreateContext: resourceGitlabProjectExportCreate,
+		ReadContext:   resourceGitlabProjectExportRead,
+		DeleteContext: resourceGitlabProjectExportDelete,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(30 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"project": {
+				Description:  "The ID or full path of the project to export.",
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"description": {
+				Description: "Overrides the description of the project in the export.",
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+			},
+			"path": {
+				Description:  "The local path the export archive is downloaded to. The directory must exist.",
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"checksum_sha256": {
+				Description: "The SHA256 checksum of the downloaded export archive.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+		},
+	}
+})
+
+func resourceGitlabProjectExportCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+	project := d.Get("project").(string)
+
+	options := &gitlab.ScheduleExportOptions{}
+	if v, ok := d.GetOk("description"); ok {
+		options.Description = gitlab.String(v.(string))
+	}
+
+	// The status of a previous export is recorded, so that a `finished` status left over from it isn't mistaken
+	// for the new export. GitLab only keeps the latest export of a project.
+	previous, _, err := client.ProjectImportExport.ExportStatus(project, gitlab.WithContext(ctx))
+	if err != nil {
+		return diag.Errorf("failed to get export status of project %s: %v", project, err)
+	}
+
+	log.Printf("[DEBUG] schedule export of gitlab project %s", project)
+
+	if _, err := client.ProjectImportExport.ScheduleExport(project, options, gitlab.WithContext(ctx)); err != nil {
+		return diag.Errorf("failed to schedule export of project %s: %v", project, err)
+	}
+
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{"none", "queued", "started", "regeneration_in_progress", "previous_finished"},
+		Target:  []string{"finished"},
+		Refresh: projectExportStatusRefreshFunc(ctx, client, project, previous, time.Now()),
+
+		Timeout:    d.Timeout(schema.TimeoutCreate),
+		MinTimeout: 3 * time.Second,
+	}
+
+	if _, err := stateConf.WaitForStateContext(ctx); err != nil {
+		return diag.Errorf("error while waiting for export of project %s to finish: %v", project, err)
+	}
+
+	path := d.Get("path").(string)
+	checksum, err := resourceGitlabProjectExportDownload(ctx, client, project, path)
+	if err != nil {
+		return diag.Errorf("failed to download export of project %s to %q: %v", project, path, err)
+	}
+
+	d.SetId(project)
+	d.Set("checksum_sha256", checksum)
+
+	return resourceGitlabProjectExportRead(ctx, d, meta)
+}
+
+// projectExportGracePeriod is how long a `finished` status after scheduling an export is attributed
+// to the previous export of the project, unless the status moved on in the meantime.
+var projectExportGracePeriod = 30 * time.Second
+
+// projectExportStatusRefreshFunc polls the export status of a project after an export was scheduled.
+// A `finished` status is only taken for the scheduled export once the status was seen to move away from the
+// `finished` status of the previous export, or once the grace period passed without that happening: GitLab reports
+// scheduled exports right away, so the scheduled export then already finished before the first poll.
+func projectExportStatusRefreshFunc(ctx context.Context, client *gitlab.Client, project string, previous *gitlab.ExportStatus, scheduled time.Time) resource.StateRefreshFunc {
+	movedOn := previous.ExportStatus != "finished"
+	return func() (interface{}, string, error) {
+		status, _, err := client.ProjectImportExport.ExportStatus(project, gitlab.WithContext(ctx))
+		if err != nil {
+			return nil, "", err
+		}
+
+		if status.ExportStatus != "finished" {
+			movedOn = true
+		}
+		if status.ExportStatus == "finished" && !movedOn && time.Since(scheduled) < projectExportGracePeriod {
+			return status, "previous_finished", nil
+		}
+		return status, status.ExportStatus, nil
+	}
+}
+
+// The export only exists as a local file, thus the resource is only recreated if the file is missing or modified.
+func resourceGitlabProjectExportRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	path := d.Get("path").(string)
+
+	log.Printf("[DEBUG] read gitlab project export %q of project %s", path, d.Id())
+
+	checksum, err := fileSHA256(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			log.Printf("[DEBUG] gitlab project export %q not found, removing from state", path)
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("failed to read gitlab project export %q: %v", path, err)
+	}
+
+	if checksum != d.Get("checksum_sha256").(string) {
+		log.Printf("[DEBUG] gitlab project export %q was modified, removing from state", path)
+		d.SetId("")
+	}
+
+	return nil
+}
+
+func resourceGitlabProjectExportDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	path := d.Get("path").(string)
+
+	log.Printf("[DEBUG] delete gitlab project export %q of project %s", path, d.Id())
+
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return diag.Errorf("failed to delete gitlab project export %q: %v", path, err)
+	}
+
+	return nil
+}
+
+// resourceGitlabProjectExportDownload streams the finished export of a project to a local file and returns its SHA256 checksum.
+func resourceGitlabProjectExportDownload(ctx context.Context, client *gitlab.Client, project string, path string) (string, error) {
+	req, err := client.NewRequest(http.MethodGet, fmt.Sprintf("projects/%s/export/download", url.PathEscape(project)), nil, []gitlab.RequestOptionFunc{gitlab.WithContext(ctx)})
+	if err != nil {
+		return "", err
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return "", err
+	}
+
+	h := sha256.New()
+	_, err = client.Do(req, io.MultiWriter(f, h))
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(path)
+		return "", err
+	}
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
@@ -0,0 +1,146 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	gitlab "github.com/xanzy/go-gitlab"
+)
+
+func TestAccGitlabProjectExport_basic(t *testing.T) {
+	testAccCheck(t)
+
+	project := testAccCreateProject(t)
+	path := filepath.Join(t.TempDir(), "export.tar.gz")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: providerFactories,
+		CheckDestroy:      testAccCheckGitlabProjectExportDestroy(path),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccGitlabProjectExportConfig(project.ID, path),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("gitlab_project_export.foo", "path", path),
+					testAccCheckGitlabProjectExportChecksum("gitlab_project_export.foo", path),
+				),
+			},
+			// Removing the local archive exports the project again
+			{
+				PreConfig: func() {
+					if err := os.Remove(path); err != nil {
+						t.Fatalf("could not remove export: %v", err)
+					}
+				},
+				Config: testAccGitlabProjectExportConfig(project.ID, path),
+				Check:  testAccCheckGitlabProjectExportChecksum("gitlab_project_export.foo", path),
+			},
+		},
+	})
+}
+
+func TestProjectExportStatusRefreshFunc(t *testing.T) {
+	cases := []struct {
+		Name      string
+		Previous  string
+		Statuses  []string
+		Scheduled time.Time
+		Expected  []string
+	}{
+		{
+			Name:      "first export",
+			Previous:  "none",
+			Statuses:  []string{"queued", "started", "finished"},
+			Scheduled: time.Now(),
+			Expected:  []string{"queued", "started", "finished"},
+		},
+		{
+			Name:      "regenerated export",
+			Previous:  "finished",
+			Statuses:  []string{"finished", "regeneration_in_progress", "finished"},
+			Scheduled: time.Now(),
+			Expected:  []string{"previous_finished", "regeneration_in_progress", "finished"},
+		},
+		{
+			Name:      "regenerated export finished before the first poll",
+			Previous:  "finished",
+			Statuses:  []string{"finished"},
+			Scheduled: time.Now().Add(-projectExportGracePeriod),
+			Expected:  []string{"finished"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			polls := 0
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/api/v4/projects/1/export" {
+					return
+				}
+				fmt.Fprintf(w, `{"id": 1, "export_status": %q}`, tc.Statuses[polls])
+				polls++
+			}))
+			t.Cleanup(server.Close)
+
+			client, err := gitlab.NewClient("token", gitlab.WithBaseURL(server.URL+"/api/v4/"))
+			if err != nil {
+				t.Fatalf("could not create client: %v", err)
+			}
+
+			refresh := projectExportStatusRefreshFunc(context.Background(), client, "1", &gitlab.ExportStatus{ExportStatus: tc.Previous}, tc.Scheduled)
+			for _, expected := range tc.Expected {
+				_, state, err := refresh()
+				if err != nil {
+					t.Fatalf("could not refresh export status: %v", err)
+				}
+				if state != expected {
+					t.Fatalf("got state %q expected %q", state, expected)
+				}
+			}
+		})
+	}
+}
+
+func testAccCheckGitlabProjectExportChecksum(name string, path string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("not found: %s", name)
+		}
+
+		checksum, err := fileSHA256(path)
+		if err != nil {
+			return fmt.Errorf("export was not downloaded: %v", err)
+		}
+		if got := rs.Primary.Attributes["checksum_sha256"]; got != checksum {
+			return fmt.Errorf("got checksum_sha256 %q; want %q", got, checksum)
+		}
+		return nil
+	}
+}
+
+func testAccCheckGitlabProjectExportDestroy(path string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if _, err := os.Stat(path); !os.IsNotExist(err) {
+			return fmt.Errorf("export %q still exists", path)
+		}
+		return nil
+	}
+}
+
+func testAccGitlabProjectExportConfig(projectID int, path string) string {
+	return fmt.Sprintf(`
+resource "gitlab_project_export" "foo" {
+  project = "%d"
+  path    = %q
+}
+	`, projectID, path)
+}
@@ -54,7 +54,7 @@ var _ = registerResource("gitlab_project_membership", func() *schema.Resource {
 })
 
 func resourceGitlabProjectMembershipCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 
 	userId := d.Get("user_id").(int)
 	projectId := d.Get("project_id").(string)
@@ -76,7 +76,7 @@ func resourceGitlabProjectMembershipCreate(ctx context.Context, d *schema.Resour
 }
 
 func resourceGitlabProjectMembershipRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 	id := d.Id()
 	log.Printf("[DEBUG] read gitlab project projectMember %s", id)
 
@@ -112,7 +112,7 @@ func projectIdAndUserIdFromId(id string) (string, int, error) {
 }
 
 func resourceGitlabProjectMembershipUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 
 	userId := d.Get("user_id").(int)
 	projectId := d.Get("project_id").(string)
@@ -131,7 +131,7 @@ func resourceGitlabProjectMembershipUpdate(ctx context.Context, d *schema.Resour
 }
 
 func resourceGitlabProjectMembershipDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 
 	id := d.Id()
 	projectId, userId, err := projectIdAndUserIdFromId(id)
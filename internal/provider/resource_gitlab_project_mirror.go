@@ -2,16 +2,44 @@ package provider
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 	"log"
+	"net/http"
 	"net/url"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/xanzy/go-gitlab"
 )
 
+var validProjectMirrorAuthMethods = []string{"password", "ssh_public_key"}
+
+// projectMirror is a gitlab.ProjectMirror with the attributes which aren't supported by go-gitlab yet.
+type projectMirror struct {
+	gitlab.ProjectMirror
+	AuthMethod        string `json:"auth_method"`
+	MirrorBranchRegex string `json:"mirror_branch_regex"`
+}
+
+// addProjectMirrorOptions are the gitlab.AddProjectMirrorOptions with the attributes which aren't supported by go-gitlab yet.
+type addProjectMirrorOptions struct {
+	gitlab.AddProjectMirrorOptions
+	AuthMethod        *string `json:"auth_method,omitempty"`
+	MirrorBranchRegex *string `json:"mirror_branch_regex,omitempty"`
+}
+
+// editProjectMirrorOptions are the gitlab.EditProjectMirrorOptions with the attributes which aren't supported by go-gitlab yet.
+type editProjectMirrorOptions struct {
+	gitlab.EditProjectMirrorOptions
+	AuthMethod        *string `json:"auth_method,omitempty"`
+	MirrorBranchRegex *string `json:"mirror_branch_regex,omitempty"`
+}
+
 var _ = registerResource("gitlab_project_mirror", func() *schema.Resource {
 	return &schema.Resource{
 		Description: `The ` + "`" + `gitlab_project_mirror` + "`" + ` resource allows to manage the lifecycle of a project mirror.
@@ -19,6 +47,10 @@ var _ = registerResource("gitlab_project_mirror", func() *schema.Resource {
 This is for *pushing* changes to a remote repository. *Pull Mirroring* can be configured using a combination of the
 import_url, mirror, and mirror_trigger_builds properties on the gitlab_project resource.
 
+With ` + "`auth_method = \"ssh_public_key\"`" + `, GitLab generates an SSH key pair for the mirror. Its public key is exported
+as ` + "`ssh_public_key`" + `, e.g. to add it as a deploy key with write access to the remote repository.
+This requires GitLab ` + gitlabFeatureMinVersions["project_mirror_public_key"] + ` or newer, older versions don't expose the public key.
+
 **Upstream API**: [GitLab REST API docs](https://docs.gitlab.com/ee/api/remote_mirrors.html)`,
 
 		CreateContext: resourceGitlabProjectMirrorCreate,
@@ -28,6 +60,7 @@ import_url, mirror, and mirror_trigger_builds properties on the gitlab_project r
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		CustomizeDiff: customizeDiffProjectMirrorAuthMethod,
 
 		Schema: map[string]*schema.Schema{
 			"project": {
@@ -83,12 +116,53 @@ import_url, mirror, and mirror_trigger_builds properties on the gitlab_project r
 				Optional:    true,
 				Default:     true,
 			},
+			"auth_method": {
+				Description:  fmt.Sprintf("The method used to authenticate with the remote repository. With `ssh_public_key`, the `url` must be an SSH URL without password. Valid values are: %s.", renderValueListForDocs(validProjectMirrorAuthMethods)),
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "password",
+				ValidateFunc: validation.StringInSlice(validProjectMirrorAuthMethods, false),
+			},
+			"ssh_public_key": {
+				Description: "The SSH public key GitLab uses to authenticate with the remote repository, if the `auth_method` is `ssh_public_key`.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"mirror_branch_regex": {
+				Description: "Only mirror the branches matching this regular expression. Requires `only_protected_branches` to be `false`. [GitLab Premium]",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"status": {
+				Description: "The status of the last update of the mirror, as refreshed on every read.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"last_update_at": {
+							Description: "The time of the last update attempt, in RFC3339 format.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"last_error": {
+							Description: "The error of the last update attempt, if it failed.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"update_status": {
+							Description: "The status of the last update attempt, e.g. `finished` or `failed`.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+					},
+				},
+			},
 		},
 	}
 })
 
 func resourceGitlabProjectMirrorCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 
 	projectID := d.Get("project").(string)
 	URL := d.Get("url").(string)
@@ -96,19 +170,31 @@ func resourceGitlabProjectMirrorCreate(ctx context.Context, d *schema.ResourceDa
 	onlyProtectedBranches := d.Get("only_protected_branches").(bool)
 	keepDivergentRefs := d.Get("keep_divergent_refs").(bool)
 
-	options := &gitlab.AddProjectMirrorOptions{
-		URL:                   &URL,
-		Enabled:               &enabled,
-		OnlyProtectedBranches: &onlyProtectedBranches,
-		KeepDivergentRefs:     &keepDivergentRefs,
+	options := &addProjectMirrorOptions{
+		AddProjectMirrorOptions: gitlab.AddProjectMirrorOptions{
+			URL:                   &URL,
+			Enabled:               &enabled,
+			OnlyProtectedBranches: &onlyProtectedBranches,
+			KeepDivergentRefs:     &keepDivergentRefs,
+		},
+		AuthMethod: gitlab.String(d.Get("auth_method").(string)),
+	}
+
+	if v, ok := d.GetOk("mirror_branch_regex"); ok {
+		options.MirrorBranchRegex = gitlab.String(v.(string))
 	}
 
 	log.Printf("[DEBUG] create gitlab project mirror for project %v", projectID)
 
-	mirror, _, err := client.ProjectMirrors.AddProjectMirror(projectID, options, gitlab.WithContext(ctx))
+	result, _, err := gitlabAPIRequest(ctx, client, http.MethodPost, fmt.Sprintf("projects/%s/remote_mirrors", url.PathEscape(projectID)), nil, options)
 	if err != nil {
 		return diag.FromErr(err)
 	}
+
+	var mirror projectMirror
+	if err := json.Unmarshal(result, &mirror); err != nil {
+		return diag.Errorf("failed to decode gitlab project mirror of project %s: %v", projectID, err)
+	}
 	d.Set("mirror_id", mirror.ID)
 
 	mirrorID := strconv.Itoa(mirror.ID)
@@ -117,7 +203,7 @@ func resourceGitlabProjectMirrorCreate(ctx context.Context, d *schema.ResourceDa
 }
 
 func resourceGitlabProjectMirrorUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 
 	mirrorID := d.Get("mirror_id").(int)
 	projectID := d.Get("project").(string)
@@ -125,14 +211,25 @@ func resourceGitlabProjectMirrorUpdate(ctx context.Context, d *schema.ResourceDa
 	onlyProtectedBranches := d.Get("only_protected_branches").(bool)
 	keepDivergentRefs := d.Get("keep_divergent_refs").(bool)
 
-	options := gitlab.EditProjectMirrorOptions{
-		Enabled:               &enabled,
-		OnlyProtectedBranches: &onlyProtectedBranches,
-		KeepDivergentRefs:     &keepDivergentRefs,
+	options := editProjectMirrorOptions{
+		EditProjectMirrorOptions: gitlab.EditProjectMirrorOptions{
+			Enabled:               &enabled,
+			OnlyProtectedBranches: &onlyProtectedBranches,
+			KeepDivergentRefs:     &keepDivergentRefs,
+		},
+	}
+
+	if d.HasChange("auth_method") {
+		options.AuthMethod = gitlab.String(d.Get("auth_method").(string))
 	}
+
+	if d.HasChange("mirror_branch_regex") {
+		options.MirrorBranchRegex = gitlab.String(d.Get("mirror_branch_regex").(string))
+	}
+
 	log.Printf("[DEBUG] update gitlab project mirror %v for %s", mirrorID, projectID)
 
-	_, _, err := client.ProjectMirrors.EditProjectMirror(projectID, mirrorID, &options, gitlab.WithContext(ctx))
+	_, _, err := gitlabAPIRequest(ctx, client, http.MethodPut, fmt.Sprintf("projects/%s/remote_mirrors/%d", url.PathEscape(projectID), mirrorID), nil, options)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -141,7 +238,7 @@ func resourceGitlabProjectMirrorUpdate(ctx context.Context, d *schema.ResourceDa
 
 // Documented remote mirrors API does not support a delete method, instead mirror is disabled.
 func resourceGitlabProjectMirrorDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 
 	enabled := false
 
@@ -166,7 +263,7 @@ func resourceGitlabProjectMirrorDelete(ctx context.Context, d *schema.ResourceDa
 }
 
 func resourceGitlabProjectMirrorRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 
 	ids := strings.Split(d.Id(), ":")
 	projectID := ids[0]
@@ -177,48 +274,112 @@ func resourceGitlabProjectMirrorRead(ctx context.Context, d *schema.ResourceData
 	}
 	log.Printf("[DEBUG] read gitlab project mirror %s id %v", projectID, mirrorID)
 
-	var mirror *gitlab.ProjectMirror
-	found := false
+	result, err := gitlabAPIGetAllPages(ctx, client, fmt.Sprintf("projects/%s/remote_mirrors", url.PathEscape(projectID)), nil, 0)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	var mirrors []*projectMirror
+	if err := json.Unmarshal(result, &mirrors); err != nil {
+		return diag.Errorf("failed to decode gitlab project mirrors of project %s: %v", projectID, err)
+	}
 
-	opts := &gitlab.ListProjectMirrorOptions{
-		Page:    1,
-		PerPage: 20,
+	var mirror *projectMirror
+	for _, m := range mirrors {
+		log.Printf("[DEBUG] project mirror found %v", m.ID)
+		if m.ID == integerMirrorID {
+			mirror = m
+			break
+		}
 	}
 
-	for {
-		mirrors, response, err := client.ProjectMirrors.ListProjectMirror(projectID, opts, gitlab.WithContext(ctx))
+	if mirror == nil {
+		d.SetId("")
+		return nil
+	}
+
+	// The public key is only available for mirrors with SSH key authentication.
+	sshPublicKey := ""
+	if mirror.AuthMethod == "ssh_public_key" {
+		supported, err := meta.(*providerMeta).supportsFeature("project_mirror_public_key")
 		if err != nil {
 			return diag.FromErr(err)
 		}
-
-		for _, m := range mirrors {
-			log.Printf("[DEBUG] project mirror found %v", m.ID)
-			if m.ID == integerMirrorID {
-				mirror = m
-				found = true
-				break
-			}
+		if !supported {
+			return diag.Errorf("gitlab project mirror %s of project %s uses SSH key authentication, reading its public key requires GitLab %s or newer", mirrorID, projectID, gitlabFeatureMinVersions["project_mirror_public_key"])
 		}
-		if response.CurrentPage >= response.TotalPages {
-			break
+
+		sshPublicKey, err = resourceGitlabProjectMirrorPublicKey(ctx, client, projectID, mirror.ID)
+		if err != nil {
+			return diag.Errorf("failed to read SSH public key of gitlab project mirror %s of project %s: %v", mirrorID, projectID, err)
 		}
-		opts.Page++
 	}
+	d.Set("ssh_public_key", sshPublicKey)
 
-	if !found {
-		d.SetId("")
+	resourceGitlabProjectMirrorSetToState(d, mirror, &projectID)
+	return nil
+}
+
+// customizeDiffProjectMirrorAuthMethod fails the plan of a mirror with SSH key authentication
+// if the GitLab instance doesn't expose the public key, which is required to set up the remote repository.
+func customizeDiffProjectMirrorAuthMethod(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if d.Get("auth_method").(string) != "ssh_public_key" || (d.Id() != "" && !d.HasChange("auth_method")) {
 		return nil
 	}
 
-	resourceGitlabProjectMirrorSetToState(d, mirror, &projectID)
+	supported, err := meta.(*providerMeta).supportsFeature("project_mirror_public_key")
+	if err != nil {
+		return err
+	}
+	if !supported {
+		return fmt.Errorf("`auth_method = \"ssh_public_key\"` requires GitLab %s or newer, which exposes the public key of the mirror", gitlabFeatureMinVersions["project_mirror_public_key"])
+	}
 	return nil
 }
 
-func resourceGitlabProjectMirrorSetToState(d *schema.ResourceData, projectMirror *gitlab.ProjectMirror, projectID *string) {
+// resourceGitlabProjectMirrorPublicKey returns the public key of the SSH key pair GitLab generated for the mirror.
+func resourceGitlabProjectMirrorPublicKey(ctx context.Context, client *gitlab.Client, projectID string, mirrorID int) (string, error) {
+	result, _, err := gitlabAPIRequest(ctx, client, http.MethodGet, fmt.Sprintf("projects/%s/remote_mirrors/%d/public_key", url.PathEscape(projectID), mirrorID), nil, nil)
+	if err != nil {
+		return "", err
+	}
+
+	var key struct {
+		PublicKey string `json:"public_key"`
+	}
+	if err := json.Unmarshal(result, &key); err != nil {
+		return "", err
+	}
+	return key.PublicKey, nil
+}
+
+func resourceGitlabProjectMirrorSetToState(d *schema.ResourceData, projectMirror *projectMirror, projectID *string) {
 	d.Set("enabled", projectMirror.Enabled)
 	d.Set("mirror_id", projectMirror.ID)
 	d.Set("keep_divergent_refs", projectMirror.KeepDivergentRefs)
 	d.Set("only_protected_branches", projectMirror.OnlyProtectedBranches)
 	d.Set("project", projectID)
 	d.Set("url", projectMirror.URL)
+	d.Set("mirror_branch_regex", projectMirror.MirrorBranchRegex)
+	d.Set("status", flattenProjectMirrorStatus(projectMirror))
+
+	// GitLab versions without SSH key authentication for mirrors don't return the auth method.
+	authMethod := projectMirror.AuthMethod
+	if authMethod == "" {
+		authMethod = "password"
+	}
+	d.Set("auth_method", authMethod)
+}
+
+func flattenProjectMirrorStatus(projectMirror *projectMirror) []map[string]interface{} {
+	lastUpdateAt := ""
+	if projectMirror.LastUpdateAt != nil {
+		lastUpdateAt = projectMirror.LastUpdateAt.Format(time.RFC3339)
+	}
+
+	return []map[string]interface{}{{
+		"last_update_at": lastUpdateAt,
+		"last_error":     projectMirror.LastError,
+		"update_status":  projectMirror.UpdateStatus,
+	}}
 }
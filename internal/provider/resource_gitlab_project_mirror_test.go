@@ -3,6 +3,7 @@ package provider
 import (
 	"errors"
 	"fmt"
+	"regexp"
 	"strconv"
 	"strings"
 	"testing"
@@ -65,6 +66,68 @@ func TestAccGitlabProjectMirror_basic(t *testing.T) {
 				ResourceName:      "gitlab_project_mirror.foo",
 				ImportState:       true,
 				ImportStateVerify: true,
+				// The status changes with every update attempt of the mirror.
+				ImportStateVerifyIgnore: []string{"status"},
+			},
+		},
+	})
+}
+
+func TestAccGitlabProjectMirror_sshPublicKey(t *testing.T) {
+	ctx := testAccGitlabProjectStart(t)
+	var miror gitlab.ProjectMirror
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: providerFactories,
+		CheckDestroy:      testAccCheckGitlabProjectMirrorDestroy,
+		Steps: []resource.TestStep{
+			// Older GitLab versions don't expose the public key, thus the plan fails
+			{
+				SkipFunc:    isGitLabVersionAtLeast(testGitlabClient, gitlabFeatureMinVersions["project_mirror_public_key"]),
+				Config:      testAccGitlabProjectMirrorConfigWithSSHPublicKey(ctx.project.PathWithNamespace),
+				ExpectError: regexp.MustCompile(`requires GitLab`),
+			},
+			// Create a mirror authenticating with an SSH key generated by GitLab
+			{
+				SkipFunc: isGitLabVersionLessThan(testGitlabClient, gitlabFeatureMinVersions["project_mirror_public_key"]),
+				Config:   testAccGitlabProjectMirrorConfigWithSSHPublicKey(ctx.project.PathWithNamespace),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckGitlabProjectMirrorExists("gitlab_project_mirror.foo", &miror),
+					resource.TestCheckResourceAttr("gitlab_project_mirror.foo", "auth_method", "ssh_public_key"),
+					resource.TestMatchResourceAttr("gitlab_project_mirror.foo", "ssh_public_key", regexp.MustCompile(`^ssh-`)),
+					resource.TestCheckResourceAttr("gitlab_project_mirror.foo", "status.#", "1"),
+				),
+			},
+			// Import
+			{
+				SkipFunc:                isGitLabVersionLessThan(testGitlabClient, gitlabFeatureMinVersions["project_mirror_public_key"]),
+				ResourceName:            "gitlab_project_mirror.foo",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"status"},
+			},
+		},
+	})
+}
+
+func TestAccGitlabProjectMirror_mirrorBranchRegex(t *testing.T) {
+	ctx := testAccGitlabProjectStart(t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: providerFactories,
+		CheckDestroy:      testAccCheckGitlabProjectMirrorDestroy,
+		Steps: []resource.TestStep{
+			{
+				SkipFunc: isRunningInCE,
+				Config:   testAccGitlabProjectMirrorConfigWithBranchRegex(ctx.project.PathWithNamespace, "^release-.*"),
+				Check:    resource.TestCheckResourceAttr("gitlab_project_mirror.foo", "mirror_branch_regex", "^release-.*"),
+			},
+			{
+				SkipFunc: isRunningInCE,
+				Config:   testAccGitlabProjectMirrorConfigWithBranchRegex(ctx.project.PathWithNamespace, "^main$"),
+				Check:    resource.TestCheckResourceAttr("gitlab_project_mirror.foo", "mirror_branch_regex", "^main$"),
 			},
 		},
 	})
@@ -185,3 +248,24 @@ resource "gitlab_project_mirror" "foo" {
 }
 	`, project)
 }
+
+func testAccGitlabProjectMirrorConfigWithSSHPublicKey(project string) string {
+	return fmt.Sprintf(`
+resource "gitlab_project_mirror" "foo" {
+  project = %q
+  url = "ssh://git@example.com/mirror.git"
+  auth_method = "ssh_public_key"
+}
+	`, project)
+}
+
+func testAccGitlabProjectMirrorConfigWithBranchRegex(project string, regex string) string {
+	return fmt.Sprintf(`
+resource "gitlab_project_mirror" "foo" {
+  project = %q
+  url = "https://example.com/mirror"
+  only_protected_branches = false
+  mirror_branch_regex = %q
+}
+	`, project, regex)
+}
@@ -0,0 +1,109 @@
+package provider
+
+import (
+	"context"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+var _ = registerResource("gitlab_project_protected_environment", func() *schema.Resource {
+	return &schema.Resource{
+		Description: `The ` + "`gitlab_project_protected_environment`" + ` resource allows to manage the lifecycle of a protected environment of a project,
+which restricts who can deploy to it. Changing any attribute recreates the protection.
+
+-> This resource requires a GitLab Enterprise instance.
+
+**Upstream API**: [GitLab REST API docs](https://docs.gitlab.com/ee/api/protected_environments.html)`,
+
+		CreateContext: resourceGitlabProjectProtectedEnvironmentCreate,
+		ReadContext:   resourceGitlabProjectProtectedEnvironmentRead,
+		DeleteContext: resourceGitlabProjectProtectedEnvironmentDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		CustomizeDiff: customizeDiffProtectedEnvironmentDeployAccessLevels,
+		Schema: constructSchema(map[string]*schema.Schema{
+			"project": {
+				Description:  "The ID or full path of the project which the protected environment is created against.",
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"environment": {
+				Description:  "The name of the environment, which may contain wildcards like `review/*`.",
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+		}, protectedEnvironmentSchema()),
+	}
+})
+
+func resourceGitlabProjectProtectedEnvironmentCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+	project := d.Get("project").(string)
+	environment := d.Get("environment").(string)
+
+	options := expandProtectEnvironmentOptions(d, environment)
+
+	log.Printf("[DEBUG] create gitlab protected environment %q for project %s", environment, project)
+
+	if err := createProtectedEnvironment(ctx, client, protectedEnvironmentsPath("projects", project), options); err != nil {
+		return meta.(*providerMeta).premiumDiagnostics(err, "failed to protect environment %q of project %s", environment, project)
+	}
+
+	d.SetId(buildTwoPartID(&project, &environment))
+
+	return resourceGitlabProjectProtectedEnvironmentRead(ctx, d, meta)
+}
+
+func resourceGitlabProjectProtectedEnvironmentRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+	project, environment, err := parseTwoPartID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Printf("[DEBUG] read gitlab protected environment %q for project %s", environment, project)
+
+	protected, err := getProtectedEnvironment(ctx, client, protectedEnvironmentsPath("projects", project), environment)
+	if err != nil {
+		if is404(err) {
+			log.Printf("[DEBUG] gitlab protected environment %q for project %s not found, removing from state", environment, project)
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("failed to read protected environment %q of project %s: %v", environment, project, err)
+	}
+
+	d.Set("project", project)
+	d.Set("environment", protected.Name)
+	d.Set("required_approval_count", protected.RequiredApprovalCount)
+
+	if err := d.Set("deploy_access_levels", flattenEnvironmentAccessDescriptions(protected.DeployAccessLevels)); err != nil {
+		return diag.Errorf("error setting deploy_access_levels: %v", err)
+	}
+
+	return nil
+}
+
+func resourceGitlabProjectProtectedEnvironmentDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+	project, environment, err := parseTwoPartID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Printf("[DEBUG] delete gitlab protected environment %q for project %s", environment, project)
+
+	if err := deleteProtectedEnvironment(ctx, client, protectedEnvironmentsPath("projects", project), environment); err != nil && !is404(err) {
+		return diag.Errorf("failed to unprotect environment %q of project %s: %v", environment, project, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,103 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccGitlabProjectProtectedEnvironment_basic(t *testing.T) {
+	testAccCheck(t)
+	testAccCheckEE(t)
+
+	project := testAccCreateProject(t)
+	users := testAccCreateUsers(t, 1)
+	testAccAddProjectMembers(t, project.ID, users) // Users must belong to the project to be allowed to deploy.
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: providerFactories,
+		CheckDestroy:      testAccCheckGitlabProtectedEnvironmentDestroy("projects", strconv.Itoa(project.ID), "production"),
+		Steps: []resource.TestStep{
+			// Each deploy access level must have exactly one of its attributes, which is validated on plan
+			{
+				PlanOnly: true,
+				Config: fmt.Sprintf(`
+resource "gitlab_project_protected_environment" "this" {
+  project     = %d
+  environment = "production"
+
+  deploy_access_levels {
+    access_level = "developer"
+    user_id      = %d
+  }
+}`, project.ID, users[0].ID),
+				ExpectError: regexp.MustCompile("must have exactly one of `access_level`, `user_id` or `group_id`"),
+			},
+			// Protect environment
+			{
+				Config: testAccGitlabProjectProtectedEnvironmentConfig(project.ID, "developer", users[0].ID, 1),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckGitlabProtectedEnvironmentExists("projects", strconv.Itoa(project.ID), "production"),
+					resource.TestCheckResourceAttr("gitlab_project_protected_environment.this", "deploy_access_levels.#", "2"),
+					resource.TestCheckResourceAttr("gitlab_project_protected_environment.this", "required_approval_count", "1"),
+				),
+			},
+			// Verify import
+			{
+				ResourceName:      "gitlab_project_protected_environment.this",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Recreate protected environment
+			{
+				Config: testAccGitlabProjectProtectedEnvironmentConfig(project.ID, "maintainer", users[0].ID, 0),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckGitlabProtectedEnvironmentExists("projects", strconv.Itoa(project.ID), "production"),
+					resource.TestCheckResourceAttr("gitlab_project_protected_environment.this", "required_approval_count", "0"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckGitlabProtectedEnvironmentExists(kind string, id string, environment string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		_, err := getProtectedEnvironment(context.Background(), testGitlabClient, protectedEnvironmentsPath(kind, id), environment)
+		return err
+	}
+}
+
+func testAccCheckGitlabProtectedEnvironmentDestroy(kind string, id string, environment string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		_, err := getProtectedEnvironment(context.Background(), testGitlabClient, protectedEnvironmentsPath(kind, id), environment)
+		if err == nil {
+			return fmt.Errorf("protected environment %q of %s %s still exists", environment, kind, id)
+		}
+		if !is404(err) {
+			return err
+		}
+		return nil
+	}
+}
+
+func testAccGitlabProjectProtectedEnvironmentConfig(projectID int, accessLevel string, userID int, requiredApprovalCount int) string {
+	return fmt.Sprintf(`
+resource "gitlab_project_protected_environment" "this" {
+  project                 = %d
+  environment             = "production"
+  required_approval_count = %d
+
+  deploy_access_levels {
+    access_level = %q
+  }
+
+  deploy_access_levels {
+    user_id = %d
+  }
+}`, projectID, requiredApprovalCount, accessLevel, userID)
+}
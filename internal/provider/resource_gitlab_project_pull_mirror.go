@@ -0,0 +1,316 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	gitlab "github.com/xanzy/go-gitlab"
+)
+
+// projectPullMirror are the details of the pull mirror of a project.
+type projectPullMirror struct {
+	URL                    string     `json:"url"`
+	UpdateStatus           string     `json:"update_status"`
+	LastError              string     `json:"last_error"`
+	LastUpdateAt           *time.Time `json:"last_update_at"`
+	LastSuccessfulUpdateAt *time.Time `json:"last_successful_update_at"`
+}
+
+var _ = registerResource("gitlab_project_pull_mirror", func() *schema.Resource {
+	return &schema.Resource{
+		Description: `The ` + "`gitlab_project_pull_mirror`" + ` resource allows to manage the pull mirror of a project,
+which periodically *pulls* changes from a remote repository. It's independent of the creation of the project.
+The mirror is updated immediately after it is created.
+
+For *push* mirrors, use the ` + "`gitlab_project_mirror`" + ` resource instead.
+
+-> The pull mirror attributes of the ` + "`gitlab_project`" + ` resource (` + "`import_url`, `mirror`" + ` etc.) must not be used for the same project.
+Instead, add ` + "`mirror`, `mirror_trigger_builds`, `mirror_overwrites_diverged_branches` and `only_mirror_protected_branches`" + ` to the
+` + "`ignore_changes`" + ` of the project, as shown in the example, otherwise the project shows the pull mirror as drift.
+Destroying this resource disables the pull mirror and removes its URL, including the credentials, from the project.
+
+-> This resource requires a GitLab Enterprise instance.
+
+**Upstream API**: [GitLab REST API docs](https://docs.gitlab.com/ee/api/projects.html#configure-pull-mirroring-for-a-project)`,
+
+		CreateContext: resourceGitlabProjectPullMirrorCreate,
+		ReadContext:   resourceGitlabProjectPullMirrorRead,
+		UpdateContext: resourceGitlabProjectPullMirrorUpdate,
+		DeleteContext: resourceGitlabProjectPullMirrorDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"project": {
+				Description: "The ID or full path of the project.",
+				Type:        schema.TypeString,
+				ForceNew:    true,
+				Required:    true,
+			},
+			"url": {
+				Description:  "The URL of the remote repository to pull from, without credentials.",
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.IsURLWithHTTPorHTTPS,
+			},
+			"username": {
+				Description: "The username to authenticate with the remote repository. **Note**: not available for imported resources.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"password": {
+				Description:  "The password or token to authenticate with the remote repository. **Note**: not available for imported resources.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Sensitive:    true,
+				RequiredWith: []string{"username"},
+			},
+			"enabled": {
+				Description: "Determines if the pull mirror is enabled.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+			},
+			"trigger_builds": {
+				Description: "Determines if pipelines are triggered for mirror updates.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+			},
+			"overwrites_diverged_branches": {
+				Description: "Determines if diverged branches are overwritten.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+			},
+			"only_protected_branches": {
+				Description: "Determines if only protected branches are mirrored.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+			},
+			"status": {
+				Description: "The status of the last update of the mirror, as refreshed on every read.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"update_status": {
+							Description: "The status of the last update attempt, e.g. `finished` or `failed`.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"last_error": {
+							Description: "The error of the last update attempt, if it failed.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"last_update_at": {
+							Description: "The time of the last update attempt, in RFC3339 format.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"last_successful_update_at": {
+							Description: "The time of the last successful update, in RFC3339 format.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+})
+
+func resourceGitlabProjectPullMirrorCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+	project := d.Get("project").(string)
+
+	importURL, err := pullMirrorImportURL(d.Get("url").(string), d.Get("username").(string), d.Get("password").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	options := &gitlab.EditProjectOptions{
+		ImportURL:                        gitlab.String(importURL),
+		Mirror:                           gitlab.Bool(d.Get("enabled").(bool)),
+		MirrorTriggerBuilds:              gitlab.Bool(d.Get("trigger_builds").(bool)),
+		MirrorOverwritesDivergedBranches: gitlab.Bool(d.Get("overwrites_diverged_branches").(bool)),
+		OnlyMirrorProtectedBranches:      gitlab.Bool(d.Get("only_protected_branches").(bool)),
+	}
+
+	log.Printf("[DEBUG] create gitlab project pull mirror for project %s", project)
+
+	if _, _, err := client.Projects.EditProject(project, options, gitlab.WithContext(ctx)); err != nil {
+		return diag.Errorf("failed to configure pull mirror of project %s: %v", project, err)
+	}
+	d.SetId(project)
+
+	if d.Get("enabled").(bool) {
+		log.Printf("[DEBUG] start pull mirroring of project %s", project)
+		if _, err := client.Projects.StartMirroringProject(project, gitlab.WithContext(ctx)); err != nil {
+			return diag.Errorf("failed to start pull mirroring of project %s: %v", project, err)
+		}
+	}
+
+	return resourceGitlabProjectPullMirrorRead(ctx, d, meta)
+}
+
+func resourceGitlabProjectPullMirrorRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+
+	log.Printf("[DEBUG] read gitlab project pull mirror of project %s", d.Id())
+
+	project, _, err := client.Projects.GetProject(d.Id(), nil, gitlab.WithContext(ctx))
+	if err != nil {
+		if is404(err) {
+			log.Printf("[DEBUG] gitlab project %s not found, removing pull mirror from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+
+	d.Set("project", d.Id())
+	d.Set("enabled", project.Mirror)
+	d.Set("trigger_builds", project.MirrorTriggerBuilds)
+	d.Set("overwrites_diverged_branches", project.MirrorOverwritesDivergedBranches)
+	d.Set("only_protected_branches", project.OnlyMirrorProtectedBranches)
+
+	mirror, err := resourceGitlabProjectPullMirrorDetails(ctx, client, d.Id())
+	if err != nil {
+		return diag.Errorf("failed to read pull mirror of project %s: %v", d.Id(), err)
+	}
+	if mirror == nil {
+		// GitLab versions without the pull mirror API only report the status of the last import.
+		mirror = &projectPullMirror{UpdateStatus: project.ImportStatus, LastError: project.ImportError}
+	}
+
+	// The URL is returned with masked credentials, which are managed in separate attributes.
+	if mirror.URL != "" {
+		if u, err := url.Parse(mirror.URL); err == nil {
+			u.User = nil
+			d.Set("url", u.String())
+		}
+	}
+	d.Set("status", flattenProjectPullMirrorStatus(mirror))
+
+	return nil
+}
+
+func resourceGitlabProjectPullMirrorUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+
+	// The import URL must be sent with every change of the mirror settings, otherwise GitLab rejects them.
+	importURL, err := pullMirrorImportURL(d.Get("url").(string), d.Get("username").(string), d.Get("password").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	options := &gitlab.EditProjectOptions{
+		ImportURL: gitlab.String(importURL),
+	}
+
+	if d.HasChange("enabled") {
+		options.Mirror = gitlab.Bool(d.Get("enabled").(bool))
+	}
+
+	if d.HasChange("trigger_builds") {
+		options.MirrorTriggerBuilds = gitlab.Bool(d.Get("trigger_builds").(bool))
+	}
+
+	if d.HasChange("overwrites_diverged_branches") {
+		options.MirrorOverwritesDivergedBranches = gitlab.Bool(d.Get("overwrites_diverged_branches").(bool))
+	}
+
+	if d.HasChange("only_protected_branches") {
+		options.OnlyMirrorProtectedBranches = gitlab.Bool(d.Get("only_protected_branches").(bool))
+	}
+
+	log.Printf("[DEBUG] update gitlab project pull mirror of project %s", d.Id())
+
+	if _, _, err := client.Projects.EditProject(d.Id(), options, gitlab.WithContext(ctx)); err != nil {
+		return diag.Errorf("failed to update pull mirror of project %s: %v", d.Id(), err)
+	}
+
+	return resourceGitlabProjectPullMirrorRead(ctx, d, meta)
+}
+
+// The pull mirror can't be removed from a project, instead it is disabled and its URL is cleared,
+// so that the credentials don't stay in the project.
+func resourceGitlabProjectPullMirrorDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+
+	log.Printf("[DEBUG] disable gitlab project pull mirror of project %s", d.Id())
+
+	options := &gitlab.EditProjectOptions{
+		Mirror:    gitlab.Bool(false),
+		ImportURL: gitlab.String(""),
+	}
+	if _, _, err := client.Projects.EditProject(d.Id(), options, gitlab.WithContext(ctx)); err != nil && !is404(err) {
+		return diag.Errorf("failed to disable pull mirror of project %s: %v", d.Id(), err)
+	}
+
+	return nil
+}
+
+// resourceGitlabProjectPullMirrorDetails returns the details of the pull mirror of a project,
+// or nil if the GitLab version doesn't support the pull mirror API.
+func resourceGitlabProjectPullMirrorDetails(ctx context.Context, client *gitlab.Client, project string) (*projectPullMirror, error) {
+	result, _, err := gitlabAPIRequest(ctx, client, http.MethodGet, fmt.Sprintf("projects/%s/mirror/pull", url.PathEscape(project)), nil, nil)
+	if err != nil {
+		if is404(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var mirror projectPullMirror
+	if err := json.Unmarshal(result, &mirror); err != nil {
+		return nil, err
+	}
+	return &mirror, nil
+}
+
+// pullMirrorImportURL returns the URL of the remote repository with the given credentials.
+func pullMirrorImportURL(rawURL string, username string, password string) (string, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "", fmt.Errorf("invalid pull mirror url: %w", err)
+	}
+
+	switch {
+	case username != "" && password != "":
+		u.User = url.UserPassword(username, password)
+	case username != "":
+		u.User = url.User(username)
+	}
+	return u.String(), nil
+}
+
+func flattenProjectPullMirrorStatus(mirror *projectPullMirror) []map[string]interface{} {
+	values := map[string]interface{}{
+		"update_status":             mirror.UpdateStatus,
+		"last_error":                mirror.LastError,
+		"last_update_at":            "",
+		"last_successful_update_at": "",
+	}
+
+	if mirror.LastUpdateAt != nil {
+		values["last_update_at"] = mirror.LastUpdateAt.Format(time.RFC3339)
+	}
+	if mirror.LastSuccessfulUpdateAt != nil {
+		values["last_successful_update_at"] = mirror.LastSuccessfulUpdateAt.Format(time.RFC3339)
+	}
+
+	return []map[string]interface{}{values}
+}
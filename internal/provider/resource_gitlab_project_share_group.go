@@ -68,7 +68,7 @@ var _ = registerResource("gitlab_project_share_group", func() *schema.Resource {
 })
 
 func resourceGitlabProjectShareGroupCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 
 	groupId := d.Get("group_id").(int)
 	projectId := d.Get("project_id").(string)
@@ -98,7 +98,7 @@ func resourceGitlabProjectShareGroupCreate(ctx context.Context, d *schema.Resour
 }
 
 func resourceGitlabProjectShareGroupRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 	id := d.Id()
 	log.Printf("[DEBUG] read gitlab project projectMember %s", id)
 
@@ -141,7 +141,7 @@ func projectIdAndGroupIdFromId(id string) (string, int, error) {
 }
 
 func resourceGitlabProjectShareGroupDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 
 	id := d.Id()
 	projectId, groupId, err := projectIdAndGroupIdFromId(id)
@@ -5,8 +5,11 @@ package provider
 import (
 	"errors"
 	"fmt"
+	"image/color"
 	"os"
+	"path/filepath"
 	"regexp"
+	"strconv"
 	"strings"
 	"testing"
 
@@ -525,6 +528,368 @@ func TestAccGitlabProject_MergeTrains(t *testing.T) {
 	})
 }
 
+func TestAccGitlabProject_resourceDefaults(t *testing.T) {
+	var project gitlab.Project
+	rInt := acctest.RandInt()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: providerFactories,
+		CheckDestroy:      testAccCheckGitlabProjectDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccGitlabProjectConfigResourceDefaults(rInt, "default1"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckGitlabProjectExists("gitlab_project.foo", &project),
+					resource.TestCheckResourceAttr("gitlab_project.foo", "tags.#", "1"),
+					resource.TestCheckTypeSetElemAttr("gitlab_project.foo", "tags.*", "tag1"),
+					resource.TestCheckResourceAttr("gitlab_project.foo", "tags_all.#", "2"),
+					resource.TestCheckTypeSetElemAttr("gitlab_project.foo", "tags_all.*", "tag1"),
+					resource.TestCheckTypeSetElemAttr("gitlab_project.foo", "tags_all.*", "default1"),
+					func(s *terraform.State) error {
+						if len(project.TagList) != 2 {
+							return fmt.Errorf("expected project to have the configured and the default tag, got %v", project.TagList)
+						}
+						return nil
+					},
+				),
+			},
+			// Changing the default tags updates the project
+			{
+				Config: testAccGitlabProjectConfigResourceDefaults(rInt, "default2"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("gitlab_project.foo", "tags.#", "1"),
+					resource.TestCheckResourceAttr("gitlab_project.foo", "tags_all.#", "2"),
+					resource.TestCheckTypeSetElemAttr("gitlab_project.foo", "tags_all.*", "default2"),
+				),
+			},
+			// Verify import
+			{
+				ResourceName:            "gitlab_project.foo",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"initialize_with_readme"},
+			},
+		},
+	})
+}
+
+func TestAccGitlabProject_deletionProtection(t *testing.T) {
+	var project gitlab.Project
+	rInt := acctest.RandInt()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: providerFactories,
+		CheckDestroy:      testAccCheckGitlabProjectDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccGitlabProjectConfigDeletionProtection(rInt, true),
+				Check:  testAccCheckGitlabProjectExists("gitlab_project.foo", &project),
+			},
+			// Destroying the project fails while deletion protection is enabled
+			{
+				Config:      testAccGitlabProjectConfigDeletionProtection(rInt, true),
+				Destroy:     true,
+				ExpectError: regexp.MustCompile("because deletion_protection is enabled"),
+			},
+			{
+				Config: testAccGitlabProjectConfigDeletionProtection(rInt, true),
+				Check:  testAccCheckGitlabProjectExists("gitlab_project.foo", &project),
+			},
+			// Disable deletion protection, so that the project can be destroyed
+			{
+				Config: testAccGitlabProjectConfigDeletionProtection(rInt, false),
+				Check:  resource.TestCheckResourceAttr("gitlab_project.foo", "deletion_protection", "false"),
+			},
+		},
+	})
+}
+
+func TestAccGitlabProject_fork(t *testing.T) {
+	testAccCheck(t)
+
+	var project gitlab.Project
+	rInt := acctest.RandInt()
+	upstream := testAccCreateProject(t)
+	otherUpstream := testAccCreateProject(t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: providerFactories,
+		CheckDestroy:      testAccCheckGitlabProjectDestroy,
+		Steps: []resource.TestStep{
+			// Create the project as fork
+			{
+				Config: testAccGitlabProjectConfigFork(rInt, fmt.Sprintf("%d", upstream.ID)),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckGitlabProjectExists("gitlab_project.fork", &project),
+					resource.TestCheckResourceAttr("gitlab_project.fork", "forked_from_project_id", fmt.Sprintf("%d", upstream.ID)),
+					resource.TestCheckResourceAttr("gitlab_project.fork", "issues_enabled", "false"),
+					func(s *terraform.State) error {
+						if project.ForkedFromProject == nil || project.ForkedFromProject.ID != upstream.ID {
+							return fmt.Errorf("expected project to be forked from project %d, got %+v", upstream.ID, project.ForkedFromProject)
+						}
+						if project.IssuesEnabled {
+							return fmt.Errorf("expected the settings to be applied to the fork")
+						}
+						return nil
+					},
+				),
+			},
+			// Verify import
+			{
+				ResourceName:      "gitlab_project.fork",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// The imported fork has an empty plan if the fork relation isn't configured
+			{
+				Config:   testAccGitlabProjectConfigFork(rInt, "null"),
+				PlanOnly: true,
+			},
+			// Remove the fork relation
+			{
+				Config: testAccGitlabProjectConfigFork(rInt, "0"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckGitlabProjectExists("gitlab_project.fork", &project),
+					resource.TestCheckResourceAttr("gitlab_project.fork", "forked_from_project_id", "0"),
+					func(s *terraform.State) error {
+						if project.ForkedFromProject != nil {
+							return fmt.Errorf("expected fork relation to be removed, got %+v", project.ForkedFromProject)
+						}
+						return nil
+					},
+				),
+			},
+			// Create a fork relation to another project
+			{
+				Config: testAccGitlabProjectConfigFork(rInt, fmt.Sprintf("%d", otherUpstream.ID)),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckGitlabProjectExists("gitlab_project.fork", &project),
+					resource.TestCheckResourceAttr("gitlab_project.fork", "forked_from_project_id", fmt.Sprintf("%d", otherUpstream.ID)),
+				),
+			},
+			// Verify import
+			{
+				ResourceName:      "gitlab_project.fork",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccGitlabProject_containerExpirationPolicy(t *testing.T) {
+	var project gitlab.Project
+	rInt := acctest.RandInt()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: providerFactories,
+		CheckDestroy:      testAccCheckGitlabProjectDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccGitlabProjectConfigContainerExpirationPolicy(rInt, `
+					enabled           = true
+					cadence           = "1d"
+					keep_n            = 5
+					older_than        = "14d"
+					name_regex_delete = ".*"
+					name_regex_keep   = "^release-.*"
+				`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckGitlabProjectExists("gitlab_project.foo", &project),
+					resource.TestCheckResourceAttr("gitlab_project.foo", "container_expiration_policy.0.enabled", "true"),
+					resource.TestCheckResourceAttr("gitlab_project.foo", "container_expiration_policy.0.cadence", "1d"),
+					resource.TestCheckResourceAttr("gitlab_project.foo", "container_expiration_policy.0.keep_n", "5"),
+					resource.TestCheckResourceAttr("gitlab_project.foo", "container_expiration_policy.0.older_than", "14d"),
+					resource.TestCheckResourceAttr("gitlab_project.foo", "container_expiration_policy.0.name_regex_delete", ".*"),
+					resource.TestCheckResourceAttr("gitlab_project.foo", "container_expiration_policy.0.name_regex_keep", "^release-.*"),
+					resource.TestCheckResourceAttrSet("gitlab_project.foo", "container_expiration_policy.0.next_run_at"),
+					func(s *terraform.State) error {
+						if project.ContainerExpirationPolicy == nil || project.ContainerExpirationPolicy.KeepN != 5 {
+							return fmt.Errorf("expected container expiration policy to be applied, got %+v", project.ContainerExpirationPolicy)
+						}
+						return nil
+					},
+				),
+			},
+			// Only change some settings, the others keep their values
+			{
+				Config: testAccGitlabProjectConfigContainerExpirationPolicy(rInt, `
+					enabled = false
+					keep_n  = 10
+				`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("gitlab_project.foo", "container_expiration_policy.0.enabled", "false"),
+					resource.TestCheckResourceAttr("gitlab_project.foo", "container_expiration_policy.0.keep_n", "10"),
+					resource.TestCheckResourceAttr("gitlab_project.foo", "container_expiration_policy.0.cadence", "1d"),
+					resource.TestCheckResourceAttr("gitlab_project.foo", "container_expiration_policy.0.older_than", "14d"),
+					resource.TestCheckResourceAttr("gitlab_project.foo", "container_expiration_policy.0.name_regex_delete", ".*"),
+				),
+			},
+			// Clear the regular expressions
+			{
+				Config: testAccGitlabProjectConfigContainerExpirationPolicy(rInt, `
+					name_regex_delete = ""
+					name_regex_keep   = ""
+				`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("gitlab_project.foo", "container_expiration_policy.0.name_regex_delete", ""),
+					resource.TestCheckResourceAttr("gitlab_project.foo", "container_expiration_policy.0.name_regex_keep", ""),
+					resource.TestCheckResourceAttr("gitlab_project.foo", "container_expiration_policy.0.keep_n", "10"),
+				),
+			},
+			// Verify import
+			{
+				ResourceName:            "gitlab_project.foo",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"container_expiration_policy.0.next_run_at"},
+			},
+		},
+	})
+}
+
+func TestAccGitlabProject_accessLevels(t *testing.T) {
+	var project gitlab.Project
+	rInt := acctest.RandInt()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: providerFactories,
+		CheckDestroy:      testAccCheckGitlabProjectDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccGitlabProjectConfigAccessLevels(rInt, `
+  issues_access_level     = "private"
+  repository_access_level = "private"
+  wiki_access_level       = "disabled"
+  forking_access_level    = "disabled"
+				`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckGitlabProjectExists("gitlab_project.foo", &project),
+					resource.TestCheckResourceAttr("gitlab_project.foo", "issues_access_level", "private"),
+					resource.TestCheckResourceAttr("gitlab_project.foo", "repository_access_level", "private"),
+					resource.TestCheckResourceAttr("gitlab_project.foo", "wiki_access_level", "disabled"),
+					resource.TestCheckResourceAttr("gitlab_project.foo", "forking_access_level", "disabled"),
+					resource.TestCheckResourceAttr("gitlab_project.foo", "merge_requests_access_level", "enabled"),
+					// The legacy booleans follow the configured access levels.
+					resource.TestCheckResourceAttr("gitlab_project.foo", "issues_enabled", "true"),
+					resource.TestCheckResourceAttr("gitlab_project.foo", "wiki_enabled", "false"),
+					func(s *terraform.State) error {
+						if project.IssuesAccessLevel != gitlab.PrivateAccessControl || project.WikiAccessLevel != gitlab.DisabledAccessControl {
+							return fmt.Errorf("expected access levels to be applied, got issues %q and wiki %q", project.IssuesAccessLevel, project.WikiAccessLevel)
+						}
+						return nil
+					},
+				),
+			},
+			// Changing a legacy boolean without an access level changes the access level as well
+			{
+				Config: testAccGitlabProjectConfigAccessLevels(rInt, `
+  issues_access_level     = "private"
+  repository_access_level = "private"
+  wiki_access_level       = "disabled"
+  forking_access_level    = "disabled"
+  snippets_enabled        = false
+				`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("gitlab_project.foo", "snippets_enabled", "false"),
+					resource.TestCheckResourceAttr("gitlab_project.foo", "snippets_access_level", "disabled"),
+				),
+			},
+			// Verify import
+			{
+				ResourceName:      "gitlab_project.foo",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Contradicting access levels and legacy booleans are rejected
+			{
+				Config: testAccGitlabProjectConfigAccessLevels(rInt, `
+  issues_access_level = "disabled"
+  issues_enabled      = true
+				`),
+				ExpectError: regexp.MustCompile(regexp.QuoteMeta("`issues_enabled = true` conflicts with `issues_access_level = \"disabled\"`")),
+			},
+		},
+	})
+}
+
+func TestAccGitlabProject_avatar(t *testing.T) {
+	var project gitlab.Project
+	rInt := acctest.RandInt()
+	avatar := filepath.Join(t.TempDir(), "avatar.png")
+	testAccWriteAvatar(t, avatar, color.RGBA{R: 255, A: 255})
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: providerFactories,
+		CheckDestroy:      testAccCheckGitlabProjectDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccGitlabProjectConfigAvatar(rInt, avatar),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckGitlabProjectExists("gitlab_project.foo", &project),
+					testAccCheckGitlabAvatarHash("gitlab_project.foo", avatar),
+					resource.TestCheckResourceAttrSet("gitlab_project.foo", "avatar_url"),
+				),
+			},
+			// Changing the content of the avatar file uploads it again
+			{
+				PreConfig: func() { testAccWriteAvatar(t, avatar, color.RGBA{B: 255, A: 255}) },
+				Config:    testAccGitlabProjectConfigAvatar(rInt, avatar),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckGitlabAvatarHash("gitlab_project.foo", avatar),
+					resource.TestCheckResourceAttrSet("gitlab_project.foo", "avatar_url"),
+				),
+			},
+			// Removing the avatar
+			{
+				Config: testAccGitlabProjectConfig(rInt),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("gitlab_project.foo", "avatar_hash", ""),
+					resource.TestCheckResourceAttr("gitlab_project.foo", "avatar_url", ""),
+				),
+			},
+		},
+	})
+}
+
+func TestAccGitlabProject_importFile(t *testing.T) {
+	testAccCheck(t)
+
+	var project gitlab.Project
+	rInt := acctest.RandInt()
+	source := testAccCreateProject(t)
+	path := filepath.Join(t.TempDir(), "export.tar.gz")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: providerFactories,
+		CheckDestroy:      testAccCheckGitlabProjectDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccGitlabProjectConfigImportFile(rInt, source.ID, path),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckGitlabProjectExists("gitlab_project.imported", &project),
+					resource.TestCheckResourceAttr("gitlab_project.imported", "description", "Imported from an export"),
+					func(s *terraform.State) error {
+						// The repository of the source project is part of the export.
+						_, _, err := testGitlabClient.RepositoryFiles.GetFile(project.ID, "README.md", &gitlab.GetFileOptions{Ref: gitlab.String(source.DefaultBranch)})
+						if err != nil {
+							return fmt.Errorf("failed to get README.md of imported project: %v", err)
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
 func TestAccGitlabProject_willError(t *testing.T) {
 	var received, defaults gitlab.Project
 	rInt := acctest.RandInt()
@@ -682,6 +1047,8 @@ func TestAccGitlabProject_transfer(t *testing.T) {
 			{
 				Config: testAccGitlabProjectTransferBetweenGroupsAfter(rInt),
 				Check: resource.ComposeTestCheckFunc(
+					// The project is transferred in-place, thus it keeps the id from the previous step.
+					testCheckResourceAttrLazy("gitlab_project.foo", "id", func() string { return strconv.Itoa(received.ID) }),
 					testAccCheckGitlabProjectExists("gitlab_project.foo", &received),
 					testAccCheckAggregateGitlabProject(&transferred, &received),
 					resource.TestCheckResourceAttrPtr("gitlab_project_variable.foo", "value", &pathAfterTransfer),
@@ -1035,11 +1402,11 @@ func testAccCheckAggregateGitlabProject(expected, received *gitlab.Project) reso
 				}
 			}
 
-			if err := resourceGitlabProjectSetToState(testGitlabClient, expectedData, expected); err != nil {
+			if err := resourceGitlabProjectSetToState(newProviderMeta(testGitlabClient), expectedData, expected); err != nil {
 				return err
 			}
 
-			if err := resourceGitlabProjectSetToState(testGitlabClient, receivedData, received); err != nil {
+			if err := resourceGitlabProjectSetToState(newProviderMeta(testGitlabClient), receivedData, received); err != nil {
 				return err
 			}
 
@@ -1409,6 +1776,11 @@ resource "gitlab_project" "imported" {
   # So that acceptance tests can be run in a gitlab organization
   # with no billing
   visibility_level = "public"
+
+  timeouts {
+    create = "30m"
+    delete = "5m"
+  }
 }
 `, rInt, importURL)
 }
@@ -1590,6 +1962,104 @@ resource "gitlab_project" "foo" {
 	`, rInt, rInt)
 }
 
+func testAccGitlabProjectConfigDeletionProtection(rInt int, deletionProtection bool) string {
+	return fmt.Sprintf(`
+resource "gitlab_project" "foo" {
+  name = "foo-%[1]d"
+  path = "foo.%[1]d"
+  description = "Terraform acceptance tests"
+  deletion_protection = %[2]t
+
+  # So that acceptance tests can be run in a gitlab organization
+  # with no billing
+  visibility_level = "public"
+}
+	`, rInt, deletionProtection)
+}
+
+func testAccGitlabProjectConfigFork(rInt int, forkedFromProjectID string) string {
+	return fmt.Sprintf(`
+resource "gitlab_project" "fork" {
+  name = "fork-%[1]d"
+  path = "fork-%[1]d"
+  description = "Terraform acceptance tests"
+  forked_from_project_id = %[2]s
+  issues_enabled = false
+
+  # So that acceptance tests can be run in a gitlab organization
+  # with no billing
+  visibility_level = "public"
+}
+	`, rInt, forkedFromProjectID)
+}
+
+func testAccGitlabProjectConfigContainerExpirationPolicy(rInt int, policy string) string {
+	return fmt.Sprintf(`
+resource "gitlab_project" "foo" {
+  name = "foo-%[1]d"
+  path = "foo.%[1]d"
+  description = "Terraform acceptance tests"
+
+  container_expiration_policy {
+    %[2]s
+  }
+
+  # So that acceptance tests can be run in a gitlab organization
+  # with no billing
+  visibility_level = "public"
+}
+	`, rInt, policy)
+}
+
+func testAccGitlabProjectConfigAccessLevels(rInt int, accessLevels string) string {
+	return fmt.Sprintf(`
+resource "gitlab_project" "foo" {
+  name = "foo-%[1]d"
+  path = "foo.%[1]d"
+  description = "Terraform acceptance tests"
+%[2]s
+  # So that acceptance tests can be run in a gitlab organization
+  # with no billing
+  visibility_level = "public"
+}
+	`, rInt, accessLevels)
+}
+
+func testAccGitlabProjectConfigAvatar(rInt int, avatar string) string {
+	return fmt.Sprintf(`
+resource "gitlab_project" "foo" {
+  name = "foo-%[1]d"
+  path = "foo.%[1]d"
+  description = "Terraform acceptance tests"
+  avatar = %[2]q
+
+  # So that acceptance tests can be run in a gitlab organization
+  # with no billing
+  visibility_level = "public"
+}
+	`, rInt, avatar)
+}
+
+func testAccGitlabProjectConfigImportFile(rInt int, sourceID int, path string) string {
+	return fmt.Sprintf(`
+resource "gitlab_project_export" "source" {
+  project = "%[2]d"
+  path    = %[3]q
+}
+
+resource "gitlab_project" "imported" {
+  name        = "imported-%[1]d"
+  path        = "imported-%[1]d"
+  description = "Imported from an export"
+  import_file = gitlab_project_export.source.path
+
+  # So that acceptance tests can be run in a gitlab organization
+  # with no billing
+  visibility_level = "public"
+}
+	`, rInt, sourceID, path)
+}
+
 func testAccGitLabProjectMergePipelinesEnabled(rInt int) string {
 	return fmt.Sprintf(`
 resource "gitlab_project" "foo" {
@@ -1620,3 +2090,24 @@ resource "gitlab_project" "foo" {
 }
 	`, rInt, rInt)
 }
+
+func testAccGitlabProjectConfigResourceDefaults(rInt int, defaultTag string) string {
+	return fmt.Sprintf(`
+provider "gitlab" {
+  resource_defaults {
+    project_tags = ["%s"]
+  }
+}
+
+resource "gitlab_project" "foo" {
+  name = "foo-%d"
+  path = "foo.%d"
+  description = "Terraform acceptance tests"
+  tags = ["tag1"]
+
+  # So that acceptance tests can be run in a gitlab organization
+  # with no billing
+  visibility_level = "public"
+}
+	`, defaultTag, rInt, rInt)
+}
@@ -79,7 +79,7 @@ var _ = registerResource("gitlab_project_variable", func() *schema.Resource {
 })
 
 func resourceGitlabProjectVariableCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 
 	project := d.Get("project").(string)
 	key := d.Get("key").(string)
@@ -113,7 +113,7 @@ func resourceGitlabProjectVariableCreate(ctx context.Context, d *schema.Resource
 }
 
 func resourceGitlabProjectVariableRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 
 	var (
 		project          string
@@ -160,7 +160,7 @@ func resourceGitlabProjectVariableRead(ctx context.Context, d *schema.ResourceDa
 }
 
 func resourceGitlabProjectVariableUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 
 	project := d.Get("project").(string)
 	key := d.Get("key").(string)
@@ -188,7 +188,7 @@ func resourceGitlabProjectVariableUpdate(ctx context.Context, d *schema.Resource
 }
 
 func resourceGitlabProjectVariableDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 	project := d.Get("project").(string)
 	key := d.Get("key").(string)
 	environmentScope := d.Get("environment_scope").(string)
@@ -88,7 +88,7 @@ func resourceGitlabServiceGithubSetToState(d *schema.ResourceData, service *gitl
 }
 
 func resourceGitlabServiceGithubCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 	project := d.Get("project").(string)
 
 	log.Printf("[DEBUG] create gitlab github service for project %s", project)
@@ -108,7 +108,7 @@ func resourceGitlabServiceGithubCreate(ctx context.Context, d *schema.ResourceDa
 }
 
 func resourceGitlabServiceGithubRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 	project := d.Get("project").(string)
 
 	log.Printf("[DEBUG] read gitlab github service for project %s", project)
@@ -136,7 +136,7 @@ func resourceGitlabServiceGithubUpdate(ctx context.Context, d *schema.ResourceDa
 }
 
 func resourceGitlabServiceGithubDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 	project := d.Get("project").(string)
 
 	log.Printf("[DEBUG] delete gitlab github service for project %s", project)
@@ -145,7 +145,7 @@ var _ = registerResource("gitlab_service_jira", func() *schema.Resource {
 })
 
 func resourceGitlabServiceJiraCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 
 	project := d.Get("project").(string)
 
@@ -166,7 +166,7 @@ func resourceGitlabServiceJiraCreate(ctx context.Context, d *schema.ResourceData
 }
 
 func resourceGitlabServiceJiraRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 	project := d.Get("project").(string)
 
 	p, resp, err := client.Projects.GetProject(project, nil, gitlab.WithContext(ctx))
@@ -229,7 +229,7 @@ func resourceGitlabServiceJiraUpdate(ctx context.Context, d *schema.ResourceData
 }
 
 func resourceGitlabServiceJiraDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 
 	project := d.Get("project").(string)
 
@@ -62,7 +62,7 @@ func resourceGitlabServicePipelinesEmailSetToState(d *schema.ResourceData, servi
 }
 
 func resourceGitlabServicePipelinesEmailCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 	project := d.Get("project").(string)
 	d.SetId(project)
 	options := &gitlab.SetPipelinesEmailServiceOptions{
@@ -82,7 +82,7 @@ func resourceGitlabServicePipelinesEmailCreate(ctx context.Context, d *schema.Re
 }
 
 func resourceGitlabServicePipelinesEmailRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 	project := d.Id()
 
 	log.Printf("[DEBUG] read gitlab pipelines emails service for project %s", project)
@@ -103,7 +103,7 @@ func resourceGitlabServicePipelinesEmailRead(ctx context.Context, d *schema.Reso
 }
 
 func resourceGitlabServicePipelinesEmailDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 	project := d.Id()
 
 	log.Printf("[DEBUG] delete gitlab pipelines email service for project %s", project)
@@ -51,7 +51,7 @@ Topics are the successors for project tags. Aside from avoiding terminology coll
 })
 
 func resourceGitlabTopicCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 	options := &gitlab.CreateTopicOptions{
 		Name: gitlab.String(d.Get("name").(string)),
 	}
@@ -73,7 +73,7 @@ func resourceGitlabTopicCreate(ctx context.Context, d *schema.ResourceData, meta
 }
 
 func resourceGitlabTopicRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 
 	topicID, err := strconv.Atoi(d.Id())
 	if err != nil {
@@ -99,7 +99,7 @@ func resourceGitlabTopicRead(ctx context.Context, d *schema.ResourceData, meta i
 }
 
 func resourceGitlabTopicUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 	options := &gitlab.UpdateTopicOptions{}
 
 	if d.HasChange("name") {
@@ -134,7 +134,7 @@ func resourceGitlabTopicDelete(ctx context.Context, d *schema.ResourceData, meta
 
 	log.Printf("[WARN] Not deleting gitlab topic %s. Instead emptying its description", d.Id())
 
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 	options := &gitlab.UpdateTopicOptions{
 		Description: gitlab.String(""),
 	}
@@ -83,7 +83,7 @@ var _ = registerResource("gitlab_user_sshkey", func() *schema.Resource {
 })
 
 func resourceGitlabUserSSHKeyCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 	userID := d.Get("user_id").(int)
 
 	options := &gitlab.AddSSHKeyOptions{
@@ -112,7 +112,7 @@ func resourceGitlabUserSSHKeyCreate(ctx context.Context, d *schema.ResourceData,
 }
 
 func resourceGitlabUserSSHKeyRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 
 	userID, keyID, err := resourceGitlabUserSSHKeyParseID(d.Id())
 	if err != nil {
@@ -161,7 +161,7 @@ func resourceGitlabUserSSHKeyRead(ctx context.Context, d *schema.ResourceData, m
 }
 
 func resourceGitlabUserSSHKeyDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 
 	userID, keyID, err := resourceGitlabUserSSHKeyParseID(d.Id())
 	if err != nil {
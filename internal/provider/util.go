@@ -1,8 +1,12 @@
 package provider
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
+	"io"
 	"net/url"
+	"os"
 	"regexp"
 	"strconv"
 	"strings"
@@ -233,29 +237,36 @@ func isGitLabVersionLessThan(client *gitlab.Client, version string) func() (bool
 
 // isGitLabVersionAtLeast is a SkipFunc that checks that the version of GitLab is at least the
 // provided wantVersion. It only checks the major and minor version numbers, not the patch.
+// Resources should use the cached version of the providerMeta instead.
 func isGitLabVersionAtLeast(client *gitlab.Client, wantVersion string) func() (bool, error) {
 	return func() (bool, error) {
-		wantMajor, wantMinor, err := parseVersionMajorMinor(wantVersion)
-		if err != nil {
-			return false, fmt.Errorf("failed to parse wanted version %q: %w", wantVersion, err)
-		}
-
 		actualVersion, _, err := client.Version.GetVersion()
 		if err != nil {
 			return false, err
 		}
 
-		actualMajor, actualMinor, err := parseVersionMajorMinor(actualVersion.Version)
-		if err != nil {
-			return false, fmt.Errorf("failed to parse actual version %q: %w", actualVersion.Version, err)
-		}
+		return compareGitLabVersionAtLeast(actualVersion.Version, wantVersion)
+	}
+}
 
-		if actualMajor == wantMajor {
-			return actualMinor >= wantMinor, nil
-		}
+// compareGitLabVersionAtLeast returns true if the actualVersion is at least the wantVersion.
+// It only compares the major and minor version numbers, not the patch.
+func compareGitLabVersionAtLeast(actualVersion string, wantVersion string) (bool, error) {
+	wantMajor, wantMinor, err := parseVersionMajorMinor(wantVersion)
+	if err != nil {
+		return false, fmt.Errorf("failed to parse wanted version %q: %w", wantVersion, err)
+	}
+
+	actualMajor, actualMinor, err := parseVersionMajorMinor(actualVersion)
+	if err != nil {
+		return false, fmt.Errorf("failed to parse actual version %q: %w", actualVersion, err)
+	}
 
-		return actualMajor > wantMajor, nil
+	if actualMajor == wantMajor {
+		return actualMinor >= wantMinor, nil
 	}
+
+	return actualMajor > wantMajor, nil
 }
 
 func parseVersionMajorMinor(version string) (int, int, error) {
@@ -286,6 +297,53 @@ func is404(err error) bool {
 	return false
 }
 
+// deletionProtectionDiagnostics returns the error diagnostic for destroying a resource with `deletion_protection` enabled.
+func deletionProtectionDiagnostics(resourceType string, path string) diag.Diagnostics {
+	return diag.Diagnostics{{
+		Severity: diag.Error,
+		Summary:  fmt.Sprintf("Cannot destroy %s %q because deletion_protection is enabled", resourceType, path),
+		Detail:   fmt.Sprintf("Set `deletion_protection = false` and apply the change before destroying the %s.", resourceType),
+	}}
+}
+
+func is403(err error) bool {
+	if errResponse, ok := err.(*gitlab.ErrorResponse); ok &&
+		errResponse.Response != nil &&
+		errResponse.Response.StatusCode == 403 {
+		return true
+	}
+	return false
+}
+
+// transferDiagnostics returns the error diagnostic for a failed transfer of a resource to another namespace.
+// GitLab rejects transfers the user isn't allowed to do with a rather generic 403,
+// thus the required permissions are explained in the detail of the diagnostic.
+func transferDiagnostics(resourceType string, path string, target string, err error) diag.Diagnostics {
+	if is403(err) {
+		return diag.Diagnostics{{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("Not allowed to transfer %s %q to %s", resourceType, path, target),
+			Detail:   fmt.Sprintf("Transferring a %s requires the Owner role for the %s and the permission to create %ss in the target namespace: %s", resourceType, resourceType, resourceType, err),
+		}}
+	}
+	return diag.Errorf("failed to transfer %s %q to %s: %s", resourceType, path, target, err)
+}
+
+// fileSHA256 returns the hex encoded SHA256 hash of the content of the local file at the given path.
+func fileSHA256(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
 // ISO 8601 date format
 const iso8601 = "2006-01-02"
 
@@ -1,6 +1,11 @@
 package provider
 
 import (
+	"errors"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
 	"testing"
 
 	gitlab "github.com/xanzy/go-gitlab"
@@ -69,3 +74,53 @@ func TestValidateURLFunc(t *testing.T) {
 		}
 	}
 }
+
+func TestTransferDiagnostics(t *testing.T) {
+	forbidden := &gitlab.ErrorResponse{Response: &http.Response{StatusCode: http.StatusForbidden, Request: &http.Request{Method: http.MethodPut, URL: &url.URL{}}}, Message: "403 Forbidden"}
+	badRequest := &gitlab.ErrorResponse{Response: &http.Response{StatusCode: http.StatusBadRequest, Request: &http.Request{Method: http.MethodPut, URL: &url.URL{}}}, Message: "{message: Transfer failed}"}
+
+	cases := []struct {
+		Err     error
+		Summary string
+	}{
+		{
+			Err:     forbidden,
+			Summary: `Not allowed to transfer project "foo/bar" to namespace 42`,
+		},
+		{
+			Err:     errors.New("You don't have permission to transfer this project"),
+			Summary: `failed to transfer project "foo/bar" to namespace 42: You don't have permission to transfer this project`,
+		},
+		{
+			Err:     badRequest,
+			Summary: `failed to transfer project "foo/bar" to namespace 42: ` + badRequest.Error(),
+		},
+	}
+
+	for _, tc := range cases {
+		diags := transferDiagnostics("project", "foo/bar", "namespace 42", tc.Err)
+		if len(diags) != 1 || diags[0].Summary != tc.Summary {
+			t.Fatalf("got %+v expected summary %q", diags, tc.Summary)
+		}
+	}
+}
+
+func TestFileSHA256(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "file")
+	if err := os.WriteFile(path, []byte("avatar"), 0600); err != nil {
+		t.Fatalf("could not write file: %v", err)
+	}
+
+	hash, err := fileSHA256(path)
+	if err != nil {
+		t.Fatalf("could not hash file: %v", err)
+	}
+	// echo -n avatar | sha256sum
+	if expected := "87bbe879c7a5f5784a70384bb49fa9513a6a3fbe4c2d388635e3c87611c03fae"; hash != expected {
+		t.Fatalf("got hash %q, expected %q", hash, expected)
+	}
+
+	if _, err := fileSHA256(filepath.Join(t.TempDir(), "missing")); err == nil {
+		t.Fatal("expected an error for a missing file")
+	}
+}